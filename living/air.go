@@ -0,0 +1,112 @@
+package living
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+)
+
+// BreathMode is the way an entity breathes, deciding where it runs out of air.
+type BreathMode uint8
+
+const (
+	// BreathesAir is the BreathMode of entities that run out of air and drown in water.
+	BreathesAir BreathMode = iota
+	// BreathesWater is the BreathMode of aquatic entities that run out of air and suffocate on land.
+	BreathesWater
+	// BreathesAnywhere is the BreathMode of entities that never run out of air.
+	BreathesAnywhere
+)
+
+// breathingDistanceBelowEyes is the lowest distance the entity can be in water and still be able to breathe
+// based on its eye height.
+const breathingDistanceBelowEyes = 0.11111111
+
+// Breathing checks if the entity is currently breathing, meaning its air supply is not being used up.
+func (l *Living) Breathing() bool {
+	return l.breathing
+}
+
+// AirSupply returns the remaining air supply of the entity.
+func (l *Living) AirSupply() time.Duration {
+	return ticksToDuration(l.airTicks)
+}
+
+// SetAirSupply sets the remaining air supply of the entity.
+func (l *Living) SetAirSupply(duration time.Duration) {
+	l.airTicks = durationToTicks(duration)
+	l.updateState()
+}
+
+// MaxAirSupply returns the maximum air supply of the entity.
+func (l *Living) MaxAirSupply() time.Duration {
+	return ticksToDuration(l.maxAirTicks)
+}
+
+// SetMaxAirSupply sets the maximum air supply of the entity.
+func (l *Living) SetMaxAirSupply(duration time.Duration) {
+	l.maxAirTicks = durationToTicks(duration)
+	l.updateState()
+}
+
+// tickAirSupply uses up the air supply of the entity if it cannot breathe, hurting it once the air supply
+// runs out, or restores it otherwise. Viewers are only updated if the breathing state or the air supply shown
+// to them changed.
+func (l *Living) tickAirSupply() {
+	wasBreathing, air := l.breathing, max(l.airTicks, 0)
+	defer func() {
+		if l.breathing != wasBreathing || max(l.airTicks, 0) != air {
+			l.updateState()
+		}
+	}()
+
+	if l.canBreathe() {
+		if !l.breathing || l.airTicks < l.maxAirTicks {
+			l.airTicks = min(l.airTicks+5, l.maxAirTicks)
+			l.breathing = l.airTicks == l.maxAirTicks
+		}
+		return
+	}
+	if r, ok := l.armour.Helmet().Enchantment(enchantment.Respiration); ok && rand.Float64() <= enchantment.Respiration.Chance(r.Level()) {
+		// Respiration grants a chance to not use up any air every tick.
+		return
+	}
+	if l.airTicks -= 1; l.airTicks <= -20 {
+		l.airTicks = 0
+		l.Hurt(2, entity.DrowningDamageSource{})
+	}
+	l.breathing = false
+}
+
+// canBreathe checks if the entity is currently able to breathe, depending on its BreathMode.
+func (l *Living) canBreathe() bool {
+	switch l.breathMode {
+	case BreathesAnywhere:
+		return true
+	case BreathesWater:
+		return l.insideOfWater()
+	}
+	_, waterBreathing := l.Effect(effect.WaterBreathing)
+	_, conduitPower := l.Effect(effect.ConduitPower)
+	return waterBreathing || conduitPower || !l.insideOfWater()
+}
+
+// insideOfWater checks if the eyes of the entity are currently under water.
+func (l *Living) insideOfWater() bool {
+	pos := cube.PosFromVec3(entity.EyePosition(l))
+	if liq, ok := l.tx.Liquid(pos); ok {
+		if _, ok := liq.(block.Water); ok {
+			d := float64(liq.SpreadDecay()) + 1
+			if liq.LiquidFalling() {
+				d = 1
+			}
+			return entity.EyePosition(l).Y() < pos.Side(cube.FaceUp).Vec3().Y()-(d/9-breathingDistanceBelowEyes)
+		}
+	}
+	return false
+}
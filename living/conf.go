@@ -4,17 +4,76 @@ import (
 	"slices"
 	"time"
 
+	"github.com/bedrock-gophers/living/living/loot"
+	"github.com/bedrock-gophers/living/living/path"
 	"github.com/df-mc/dragonfly/server/entity"
-	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
 )
 
 type Config struct {
 	world.EntityType
+	// Identifier is the identifier the Config is registered with using Register. If empty, the EncodeEntity()
+	// value of the EntityType is used.
+	Identifier string
 	*entity.MovementComputer
 	Speed, EyeHeight, MaxHealth float64
 	Drops                       []Drop
-	ImmuneDuration              time.Duration
+	// Loot is the loot table rolled when the entity dies, in addition to its Drops.
+	Loot loot.Table
+	// Experience is the experience dropped when the entity dies.
+	Experience     ExperienceDrop
+	ImmuneDuration time.Duration
+	// RegenerationInterval is the interval at which the entity passively regains one point of health. If 0, the
+	// entity does not regain health passively.
+	RegenerationInterval time.Duration
+	// Undead specifies if the entity is undead, which makes Instant Health hurt it and Instant Damage heal it,
+	// and makes weapons enchanted with Smite deal extra damage to it.
+	Undead bool
+	// BurnsInSunlight specifies if the entity catches fire when exposed to the sky during the day, unless it is
+	// wearing a helmet or standing in water or rain, like zombies and skeletons.
+	BurnsInSunlight bool
+	// BreathMode decides where the entity runs out of air. By default, entities drown in water.
+	BreathMode BreathMode
+	// MaxAirSupply is the duration the entity can go without breathing before it starts drowning or
+	// suffocating. If 0, an air supply of 15 seconds is used.
+	MaxAirSupply time.Duration
+	// IgnoreSuffocation specifies if the entity is unaffected by being inside solid blocks, for example for
+	// ghost-like entities.
+	IgnoreSuffocation bool
+	// PushOutOfBlocks specifies if the entity is pushed out of solid blocks its eyes are inside of.
+	PushOutOfBlocks bool
+	// FireImmune specifies if the entity is immune to fire, preventing it from being set on fire or hurt by
+	// fire, lava and magma blocks.
+	FireImmune bool
+	// FreezeImmune specifies if the entity is immune to freezing in powder snow.
+	FreezeImmune bool
+	// FreezingBlock checks if a block freezes the entity while it is inside of it. If nil, PowderSnow is used,
+	// which only works with a custom powder snow block as dragonfly does not implement it.
+	FreezingBlock func(b world.Block) bool
+	// KnockBackResistance is the resistance of the entity to being knocked back, ranging from 0 to 1.
+	KnockBackResistance float64
+	// AttackDamage is the damage dealt by the entity when it attacks another entity in melee.
+	AttackDamage float64
+	// AttackReach is the maximum distance between the eyes of the entity and the bounding box of the entities
+	// it attacks. If 0, a reach of 2 blocks is used.
+	AttackReach float64
+	// AttackCooldown is the duration the entity has to wait between attacks. If 0, a cooldown of 1 second is
+	// used.
+	AttackCooldown time.Duration
+	// DeathDuration is the duration of the death animation, after which the entity is removed from the world.
+	// If 0, a duration of 1.1 seconds is used.
+	DeathDuration time.Duration
+	// Equipment is the armour and held items the entity is spawned with, and their drop chances.
+	Equipment Equipment
+	// CanPickUpItems specifies if the entity picks up items lying on the ground near it.
+	CanPickUpItems bool
+	// PickupComparator decides if the candidate item picked up should replace the current item equipped in
+	// its slot. If nil, BetterEquipment is used.
+	PickupComparator func(current, candidate item.Stack) bool
+	Goals            []PrioritisedGoal
+	Navigation       path.Config
 	Handler
 }
 
@@ -22,21 +81,88 @@ func (c Config) Apply(data *world.EntityData) {
 	if c.EntityType == nil {
 		panic("entity type can't be nil")
 	}
+	data.Data = c.newData()
+}
 
+// newData creates the livingData of an entity using the values of the Config.
+func (c Config) newData() *livingData {
 	if c.Handler == nil {
 		c.Handler = NopHandler{}
 	}
-
-	data.Data = &livingData{
-		entityType:     c.EntityType,
-		mc:             c.MovementComputer,
-		speed:          c.Speed,
-		eyeHeight:      c.EyeHeight,
-		HealthManager:  entity.NewHealthManager(c.MaxHealth, c.MaxHealth),
-		drops:          slices.Values(c.Drops),
-		scale:          1,
-		immuneDuration: c.ImmuneDuration,
-		effects:        make(map[effect.Type]effect.Effect),
-		handler:        c.Handler,
+	if c.DeathDuration == 0 {
+		c.DeathDuration = time.Millisecond * 1100
+	}
+	if c.AttackReach == 0 {
+		c.AttackReach = 2
+	}
+	if c.AttackCooldown == 0 {
+		c.AttackCooldown = time.Second
+	}
+	if c.MaxAirSupply == 0 {
+		c.MaxAirSupply = time.Second * 15
+	}
+	if c.FreezingBlock == nil {
+		c.FreezingBlock = PowderSnow
+	}
+	if c.PickupComparator == nil {
+		c.PickupComparator = BetterEquipment
+	}
+	if c.Identifier == "" && c.EntityType != nil {
+		c.Identifier = c.EntityType.EncodeEntity()
+	}
+	d := &livingData{
+		identifier:           c.Identifier,
+		entityType:           c.EntityType,
+		mc:                   c.MovementComputer,
+		speed:                c.Speed,
+		eyeHeight:            c.EyeHeight,
+		HealthManager:        entity.NewHealthManager(c.MaxHealth, c.MaxHealth),
+		drops:                slices.Values(c.Drops),
+		loot:                 c.Loot,
+		experience:           c.Experience,
+		scale:                1,
+		immuneDuration:       durationToTicks(c.ImmuneDuration),
+		deathDuration:        c.DeathDuration,
+		effects:              entity.NewEffectManager(),
+		canPickUpItems:       c.CanPickUpItems,
+		pickupComparator:     c.PickupComparator,
+		inventory:            inventory.New(inventorySize, nil),
+		regenerationInterval: durationToTicks(c.RegenerationInterval),
+		breathMode:           c.BreathMode,
+		breathing:            true,
+		airTicks:             durationToTicks(c.MaxAirSupply),
+		maxAirTicks:          durationToTicks(c.MaxAirSupply),
+		ignoresSuffocation:   c.IgnoreSuffocation,
+		pushOutOfBlocks:      c.PushOutOfBlocks,
+		fireImmune:           c.FireImmune,
+		freezeImmune:         c.FreezeImmune,
+		freezingBlock:        c.FreezingBlock,
+		undead:               c.Undead,
+		burnsInSunlight:      c.BurnsInSunlight,
+		knockBackResistance:  c.KnockBackResistance,
+		attackDamage:         c.AttackDamage,
+		attackReach:          c.AttackReach,
+		attackCooldown:       durationToTicks(c.AttackCooldown),
+		handler:              c.Handler,
+		goals:                NewGoalSelector(c.Goals...),
+		navigator:            NewNavigator(c.Navigation),
+	}
+	d.armour = inventory.NewArmour(func(int, item.Stack, item.Stack) {
+		d.armourChanged = true
+	})
+	for slot, e := range c.Equipment.slots() {
+		if e.DropChance == 0 {
+			e.DropChance = DefaultDropChance
+		}
+		d.dropChances[slot] = e.DropChance
+		switch EquipmentSlot(slot) {
+		case SlotMainHand:
+			d.mainHand = e.Stack
+		case SlotOffHand:
+			d.offHand = e.Stack
+		default:
+			_ = d.armour.Inventory().SetItem(slot, e.Stack)
+		}
 	}
+	return d
 }
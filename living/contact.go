@@ -0,0 +1,104 @@
+package living
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// HotFloorDamageSource is used for damage caused by standing on a magma block.
+type HotFloorDamageSource struct{}
+
+func (HotFloorDamageSource) ReducedByResistance() bool { return true }
+func (HotFloorDamageSource) ReducedByArmour() bool     { return true }
+func (HotFloorDamageSource) Fire() bool                { return true }
+func (HotFloorDamageSource) IgnoreTotem() bool         { return false }
+
+// FireImmune checks if the entity is immune to fire, preventing it from being set on fire or hurt by fire, lava
+// and magma blocks.
+func (l *Living) FireImmune() bool {
+	return l.fireImmune
+}
+
+// Sneaking checks if the entity is sneaking. Sneaking entities are not hurt by standing on magma blocks.
+func (l *Living) Sneaking() bool {
+	return l.sneaking
+}
+
+// StartSneaking makes the entity start sneaking.
+func (l *Living) StartSneaking() {
+	if l.sneaking {
+		return
+	}
+	l.sneaking = true
+	l.updateState()
+}
+
+// StopSneaking makes the entity stop sneaking.
+func (l *Living) StopSneaking() {
+	if !l.sneaking {
+		return
+	}
+	l.sneaking = false
+	l.updateState()
+}
+
+// tickBlockContact hurts the entity for touching or standing on blocks that deal damage on contact. Blocks
+// that dragonfly does not implement, such as magma blocks and sweet berry bushes, are recognised by their name,
+// so that custom implementations of them hurt the entity too.
+func (l *Living) tickBlockContact(current int64, moved mgl64.Vec3) {
+	box := l.entityType.BBox(l).Translate(l.Position())
+
+	// Entities cannot move into cactus, so they are hurt when touching it from the side.
+	touching := box.GrowVec3(mgl64.Vec3{0.01, 0, 0.01})
+	low, high := cube.PosFromVec3(touching.Min()), cube.PosFromVec3(touching.Max())
+	for y := low[1]; y <= high[1]; y++ {
+		for x := low[0]; x <= high[0]; x++ {
+			for z := low[2]; z <= high[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				b := l.tx.Block(pos)
+				if c, ok := b.(block.Cactus); ok && l.touches(touching, pos, b) {
+					c.EntityInside(pos, l.tx, l)
+				} else if grownBerryBush(b) && current%10 == 0 && (moved[0] != 0 || moved[2] != 0) {
+					l.Hurt(1, block.DamageSource{Block: b})
+				}
+			}
+		}
+	}
+
+	if !l.onGround || l.sneaking || l.fireImmune || current%10 != 0 {
+		return
+	}
+	below := cube.PosFromVec3(l.Position().Sub(mgl64.Vec3{0, 0.01}))
+	if blockName(l.tx.Block(below)) == "minecraft:magma" {
+		l.Hurt(1, HotFloorDamageSource{})
+	}
+}
+
+// touches checks if the box passed intersects with any of the collision boxes of the block at pos.
+func (l *Living) touches(box cube.BBox, pos cube.Pos, b world.Block) bool {
+	for _, bb := range b.Model().BBox(pos, l.tx) {
+		if bb.Translate(pos.Vec3()).IntersectsWith(box) {
+			return true
+		}
+	}
+	return false
+}
+
+// grownBerryBush checks if the block passed is a sweet berry bush that has grown past its first stage, which
+// hurts entities moving through it.
+func grownBerryBush(b world.Block) bool {
+	name, properties := b.EncodeBlock()
+	if name != "minecraft:sweet_berry_bush" {
+		return false
+	}
+	growth, _ := properties["growth"].(int32)
+	return growth > 0
+}
+
+// blockName returns the name of the block passed.
+func blockName(b world.Block) string {
+	name, _ := b.EncodeBlock()
+	return name
+}
@@ -0,0 +1,84 @@
+package living
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Damage holds the damage dealt to an entity after each stage of the damage pipeline in Hurt. Each stage
+// reduces the damage left after the stage before it.
+type Damage struct {
+	// Raw is the damage passed to Hurt.
+	Raw float64
+	// Armour is the damage left after the defence points and toughness of the armour worn by the entity.
+	Armour float64
+	// Enchantments is the damage left after the protection enchantments of the armour worn by the entity.
+	Enchantments float64
+	// Effects is the damage left after the effects of the entity, such as Resistance.
+	Effects float64
+	// Absorption is the damage left after the absorption health of the entity is used up, which is the damage
+	// dealt to its health unless Final is changed by HandleHurt.
+	Absorption float64
+	// Final is the damage dealt to the entity, of which absorption health takes the first part. It is equal to
+	// Effects, unless changed by HandleHurt.
+	Final float64
+}
+
+// FinalDamageFrom returns the damage the entity takes from the damage source passed, after armour, protection
+// enchantments and effects reduce it.
+func (l *Living) FinalDamageFrom(dmg float64, src world.DamageSource) float64 {
+	return l.damageFrom(dmg, src).Final
+}
+
+// damageFrom runs the damage passed through the stages of the damage pipeline.
+func (l *Living) damageFrom(dmg float64, src world.DamageSource) Damage {
+	d := Damage{Raw: max(dmg, 0)}
+
+	var defencePoints, toughness float64
+	var enchantments []item.Enchantment
+	for _, it := range l.armour.Items() {
+		enchantments = append(enchantments, it.Enchantments()...)
+		if armour, ok := it.Item().(item.Armour); ok {
+			defencePoints += armour.DefencePoints()
+			toughness += armour.Toughness()
+		}
+	}
+
+	d.Armour = d.Raw
+	if src.ReducedByArmour() {
+		// Every effective armour point reduces the damage by 4%. Effective armour points decrease as the damage
+		// increases, unless compensated by toughness, but are at least 20% of the armour points.
+		d.Armour -= d.Armour * 0.04 * math.Max(defencePoints*0.2, defencePoints-d.Armour/(2+toughness/4))
+	}
+	d.Enchantments = d.Armour - d.Armour*enchantment.ProtectionFactor(src, enchantments)
+
+	d.Effects = d.Enchantments
+	if res, ok := l.Effect(effect.Resistance); ok {
+		d.Effects *= effect.Resistance.Multiplier(src, res.Level())
+	}
+	d.Absorption = max(d.Effects-l.absorption, 0)
+	d.Final = d.Effects
+	return d
+}
+
+// Absorption returns the absorption health of the entity, which is lost before its actual health when hurt.
+func (l *Living) Absorption() float64 {
+	return l.absorption
+}
+
+// SetAbsorption sets the absorption health of the entity. Absorption health does not regenerate once lost.
+// Negative values are treated as 0. Dragonfly does not include absorption health in the state sent to viewers.
+func (l *Living) SetAbsorption(health float64) {
+	l.absorption = max(health, 0)
+	l.updateState()
+}
+
+// PassiveHealingSource is a healing source used when an entity regains health passively over time, as set
+// using Config.RegenerationInterval.
+type PassiveHealingSource struct{}
+
+func (PassiveHealingSource) HealingSource() {}
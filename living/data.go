@@ -1,14 +1,18 @@
 package living
 
 import (
+	"github.com/bedrock-gophers/living/living/loot"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
 	"iter"
 	"time"
 )
 
 type livingData struct {
+	identifier string
 	entityType world.EntityType
 
 	age       time.Duration
@@ -16,8 +20,25 @@ type livingData struct {
 	speed     float64
 	eyeHeight float64
 	*entity.HealthManager
+	absorption float64
+	// regenerationInterval is the amount of ticks between every point of health regained passively.
+	regenerationInterval int64
+	undead               bool
+	burnsInSunlight      bool
 
-	drops iter.Seq[Drop]
+	breathMode BreathMode
+	breathing  bool
+	// airTicks is the remaining amount of ticks the entity can go without breathing. maxAirTicks is the amount
+	// of ticks the entity can go without breathing when its air supply is full.
+	airTicks    int64
+	maxAirTicks int64
+
+	ignoresSuffocation bool
+	pushOutOfBlocks    bool
+
+	drops      iter.Seq[Drop]
+	loot       loot.Table
+	experience ExperienceDrop
 
 	collidedHorizontally bool
 	collidedVertically   bool
@@ -29,14 +50,59 @@ type livingData struct {
 
 	fallDistance float64
 	fireTicks    int64
+	fireImmune   bool
+	// frozenTicks is the amount of ticks the entity has been freezing for, up to freezeTicks. fullyFrozenTicks
+	// is the amount of ticks the entity has been fully frozen for.
+	frozenTicks      int64
+	fullyFrozenTicks int64
+	freezeImmune     bool
+	freezingBlock    func(b world.Block) bool
+	sneaking         bool
 
-	immuneUntil    time.Time
-	immuneDuration time.Duration
+	// immuneTicks is the remaining amount of ticks the entity is immune to attacks. immuneDuration is the
+	// amount of ticks the entity becomes immune for after being hurt.
+	immuneTicks    int64
+	immuneDuration int64
 	lastDamage     float64
 
-	effects map[effect.Type]effect.Effect
+	effects *entity.EffectManager
+	// loadedEffects are the effects the entity was loaded with, which are added to its effects the first time
+	// they are used.
+	loadedEffects []effect.Effect
+
+	// armourChanged is set when the armour of the entity changes, so that it is sent to viewers in the next
+	// tick.
+	armour            *inventory.Armour
+	armourChanged     bool
+	mainHand, offHand item.Stack
+	dropChances       [6]float64
+
+	canPickUpItems   bool
+	pickupComparator func(current, candidate item.Stack) bool
+	inventory        *inventory.Inventory
+
+	knockBackResistance float64
+	// knockBackBonus is the extra knock back force applied by the next KnockBack in the current tick, set when
+	// the entity is hurt by a sprinting attacker.
+	knockBackBonus float64
+
+	attackDamage float64
+	attackReach  float64
+	// attackTicks is the remaining amount of ticks before the entity can attack again. attackCooldown is the
+	// amount of ticks the entity has to wait between attacks.
+	attackTicks    int64
+	attackCooldown int64
+	charging       bool
+
+	// closed is set when the entity is closed, for example after being transformed, so that it stops ticking.
+	closed        bool
+	dying         bool
+	deathTicks    int64
+	deathDuration time.Duration
 
-	handler Handler
+	handler   Handler
+	goals     *GoalSelector
+	navigator *Navigator
 
 	variant     int32
 	markVariant int32
@@ -10,32 +10,99 @@ type Drop struct {
 	it       world.Item
 	min, max int
 	stack    *item.Stack
+
+	chance, lootingChance float64
+	lootingBonus          int
 }
 
 // NewDrop ...
 func NewDrop(it world.Item, min, max int) Drop {
 	return Drop{
-		it:  it,
-		min: min,
-		max: max,
+		it:     it,
+		min:    min,
+		max:    max,
+		chance: 1,
 	}
 }
 
 // NewDropWithStack ...
 func NewDropWithStack(stack item.Stack) Drop {
 	return Drop{
-		stack: &stack,
+		stack:  &stack,
+		chance: 1,
 	}
 }
 
+// WithChance returns a copy of the Drop that is only dropped with the chance passed, ranging from 0 to 1.
+func (d Drop) WithChance(chance float64) Drop {
+	d.chance = chance
+	return d
+}
+
+// WithLootingChance returns a copy of the Drop whose chance to be dropped increases by the amount passed for
+// every level of Looting on the weapon the entity was killed with.
+func (d Drop) WithLootingChance(perLevel float64) Drop {
+	d.lootingChance = perLevel
+	return d
+}
+
+// WithLootingBonus returns a copy of the Drop that drops up to the amount of extra items passed for every
+// level of Looting on the weapon the entity was killed with.
+func (d Drop) WithLootingBonus(perLevel int) Drop {
+	d.lootingBonus = perLevel
+	return d
+}
+
 // Stack ...
 func (d Drop) Stack() item.Stack {
+	return d.LootingStack(0)
+}
+
+// LootingStack returns the item stack dropped when the entity is killed with a weapon with the Looting level
+// passed. An empty stack is returned if nothing is dropped.
+func (d Drop) LootingStack(level int) item.Stack {
+	if rand.Float64() >= d.chance+d.lootingChance*float64(level) {
+		return item.Stack{}
+	}
+	bonus := 0
+	if n := d.lootingBonus * level; n > 0 {
+		bonus = rand.IntN(n + 1)
+	}
 	if d.stack != nil {
-		return *d.stack
+		return d.stack.Grow(bonus)
+	}
+	c := d.min
+	if d.max > d.min {
+		c += rand.IntN(d.max - d.min)
 	}
-	c := rand.IntN(d.max-d.min) + d.min
-	if c == 0 {
+	if c += bonus; c <= 0 {
 		return item.Stack{}
 	}
 	return item.NewStack(d.it, c)
 }
+
+// ExperienceDrop is the amount of experience dropped by an entity when it dies.
+type ExperienceDrop struct {
+	// Min and Max are the minimum and maximum amount of experience dropped, both inclusive. If Max is lower
+	// than Min, exactly Min experience is dropped.
+	Min, Max int
+	// PlayerKillOnly specifies if the experience is only dropped if the entity was killed by a player.
+	PlayerKillOnly bool
+}
+
+// FixedExperience returns an ExperienceDrop that always drops the amount of experience passed.
+func FixedExperience(amount int) ExperienceDrop {
+	return ExperienceDrop{Min: amount, Max: amount}
+}
+
+// Amount returns a random amount of experience within the range of the ExperienceDrop. If the ExperienceDrop
+// is only dropped on player kills and killedByPlayer is false, 0 is returned.
+func (e ExperienceDrop) Amount(killedByPlayer bool) int {
+	if e.PlayerKillOnly && !killedByPlayer {
+		return 0
+	}
+	if e.Max <= e.Min {
+		return e.Min
+	}
+	return e.Min + rand.IntN(e.Max-e.Min+1)
+}
@@ -0,0 +1,113 @@
+package living
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Looting is a sword enchantment that increases the amount of items dropped by entities killed with it.
+// Dragonfly does not implement Looting, so it is registered by this package instead.
+var Looting looting
+
+// Smite is a sword or axe enchantment that increases the damage dealt to undead entities. Dragonfly does not
+// implement Smite, so it is registered by this package instead.
+var Smite smite
+
+func init() {
+	item.RegisterEnchantment(10, Smite)
+	item.RegisterEnchantment(14, Looting)
+}
+
+type looting struct{}
+
+// Name ...
+func (looting) Name() string {
+	return "Looting"
+}
+
+// MaxLevel ...
+func (looting) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (looting) Cost(level int) (int, int) {
+	minCost := 15 + (level-1)*9
+	return minCost, minCost + 50
+}
+
+// Rarity ...
+func (looting) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// CompatibleWithEnchantment ...
+func (looting) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t.Name() != "Silk Touch"
+}
+
+// CompatibleWithItem ...
+func (looting) CompatibleWithItem(i world.Item) bool {
+	t, ok := i.(item.Tool)
+	return ok && t.ToolType() == item.TypeSword
+}
+
+type smite struct{}
+
+// Name ...
+func (smite) Name() string {
+	return "Smite"
+}
+
+// MaxLevel ...
+func (smite) MaxLevel() int {
+	return 5
+}
+
+// Cost ...
+func (smite) Cost(level int) (int, int) {
+	minCost := 5 + (level-1)*8
+	return minCost, minCost + 20
+}
+
+// Rarity ...
+func (smite) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityUncommon
+}
+
+// Addend returns the additional damage dealt to undead entities when attacking with smite.
+func (smite) Addend(level int) float64 {
+	return float64(level) * 2.5
+}
+
+// CompatibleWithEnchantment ...
+func (smite) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t.Name() != "Sharpness" && t.Name() != "Bane of Arthropods"
+}
+
+// CompatibleWithItem ...
+func (smite) CompatibleWithItem(i world.Item) bool {
+	t, ok := i.(item.Tool)
+	return ok && (t.ToolType() == item.TypeSword || t.ToolType() == item.TypeAxe)
+}
+
+// lootingLevel returns the level of Looting on the item stack passed, or 0 if it does not have Looting.
+func lootingLevel(s item.Stack) int {
+	if e, ok := s.Enchantment(Looting); ok {
+		return e.Level()
+	}
+	return 0
+}
+
+// smiteBonus returns the extra damage dealt to the entity by the damage source passed if the entity is undead
+// and the source is an attack with a weapon enchanted with Smite.
+func (l *Living) smiteBonus(src world.DamageSource) float64 {
+	if !l.undead {
+		return 0
+	}
+	_, weapon := killer(src)
+	if e, ok := weapon.Enchantment(Smite); ok {
+		return Smite.Addend(e.Level())
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package living
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+var _ = item.Carrier(&Living{})
+
+// EquipmentSlot is a slot that an entity may equip an item in.
+type EquipmentSlot int
+
+const (
+	SlotHelmet EquipmentSlot = iota
+	SlotChestplate
+	SlotLeggings
+	SlotBoots
+	SlotMainHand
+	SlotOffHand
+)
+
+// DefaultDropChance is the chance that equipment is dropped when an entity dies, unless another chance is set.
+const DefaultDropChance = 0.085
+
+// EquippedItem is an item equipped in a slot of an entity, together with the chance of it being dropped when
+// the entity dies.
+type EquippedItem struct {
+	Stack item.Stack
+	// DropChance is the chance, ranging from 0 to 1, that the item is dropped when the entity dies. If 0,
+	// DefaultDropChance is used. A negative chance prevents the item from being dropped at all. Items dropped
+	// with a chance lower than 1 have their durability randomised.
+	DropChance float64
+}
+
+// Equipment holds the items an entity is spawned with.
+type Equipment struct {
+	Helmet, Chestplate, Leggings, Boots, MainHand, OffHand EquippedItem
+}
+
+// slots returns the items of the Equipment ordered by their EquipmentSlot.
+func (e Equipment) slots() [6]EquippedItem {
+	return [6]EquippedItem{e.Helmet, e.Chestplate, e.Leggings, e.Boots, e.MainHand, e.OffHand}
+}
+
+// Armour returns the armour inventory of the entity. Changes made to it are shown to viewers in the next tick.
+func (l *Living) Armour() *inventory.Armour {
+	return l.armour
+}
+
+// HeldItems returns the items held in the main hand and off-hand of the entity.
+func (l *Living) HeldItems() (mainHand, offHand item.Stack) {
+	return l.mainHand, l.offHand
+}
+
+// SetHeldItems sets the items held in the main hand and off-hand of the entity. The stacks passed may be empty
+// to clear the held items.
+func (l *Living) SetHeldItems(mainHand, offHand item.Stack) {
+	l.mainHand, l.offHand = mainHand, offHand
+	for _, v := range l.Viewers() {
+		v.ViewEntityItems(l)
+	}
+}
+
+// Equipped returns the item equipped in the slot passed.
+func (l *Living) Equipped(slot EquipmentSlot) item.Stack {
+	switch slot {
+	case SlotMainHand:
+		return l.mainHand
+	case SlotOffHand:
+		return l.offHand
+	}
+	s, _ := l.armour.Inventory().Item(int(slot))
+	return s
+}
+
+// Equip equips the item stack passed in the slot passed. The drop chance of the slot is left unchanged. An error
+// is returned if the item cannot be worn in the armour slot passed.
+func (l *Living) Equip(slot EquipmentSlot, s item.Stack) error {
+	switch slot {
+	case SlotMainHand:
+		l.SetHeldItems(s, l.offHand)
+	case SlotOffHand:
+		l.SetHeldItems(l.mainHand, s)
+	default:
+		return l.armour.Inventory().SetItem(int(slot), s)
+	}
+	return nil
+}
+
+// DropChance returns the chance that the item in the slot passed is dropped when the entity dies.
+func (l *Living) DropChance(slot EquipmentSlot) float64 {
+	return l.dropChances[slot]
+}
+
+// SetDropChance sets the chance, ranging from 0 to 1, that the item in the slot passed is dropped when the
+// entity dies. Items picked up by the entity are usually dropped with a chance of 1.
+func (l *Living) SetDropChance(slot EquipmentSlot, chance float64) {
+	l.dropChances[slot] = chance
+}
+
+// equipmentDrops returns the equipment of the entity that is dropped when it dies, taking the drop chance of
+// each slot and the Looting level passed into account.
+func (l *Living) equipmentDrops(looting int) []item.Stack {
+	var stacks []item.Stack
+	for slot, chance := range l.dropChances {
+		s := l.Equipped(EquipmentSlot(slot))
+		if s.Empty() || rand.Float64() >= chance+float64(looting)*0.01 {
+			continue
+		}
+		if chance < 1 && s.MaxDurability() > 0 {
+			// Equipment that the entity was spawned with is dropped with random durability.
+			s = s.WithDurability(1 + rand.IntN(s.MaxDurability()))
+		}
+		stacks = append(stacks, s)
+	}
+	return stacks
+}
+
+// updateArmour shows the armour of the entity to its viewers if it changed since the last call.
+func (l *Living) updateArmour() {
+	if !l.armourChanged {
+		return
+	}
+	l.armourChanged = false
+	for _, v := range l.Viewers() {
+		v.ViewEntityArmour(l)
+	}
+}
+
+// damageItem deals d damage points to the item stack passed, taking Unbreaking into account, and returns the
+// resulting stack.
+func (l *Living) damageItem(s item.Stack, d int) item.Stack {
+	if d == 0 || s.MaxDurability() == -1 {
+		return s
+	}
+	if e, ok := s.Enchantment(enchantment.Unbreaking); ok {
+		d = enchantment.Unbreaking.Reduce(s.Item(), e.Level(), d)
+	}
+	if s = s.Damage(d); s.Empty() {
+		l.tx.PlaySound(l.Position(), sound.ItemBreak{})
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package living
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// freezeTicks is the amount of ticks an entity has to spend in powder snow to become fully frozen.
+const freezeTicks = 140
+
+// FreezingDamageSource is used for damage caused by being fully frozen in powder snow.
+type FreezingDamageSource struct{}
+
+func (FreezingDamageSource) ReducedByResistance() bool { return true }
+func (FreezingDamageSource) ReducedByArmour() bool     { return false }
+func (FreezingDamageSource) Fire() bool                { return false }
+func (FreezingDamageSource) IgnoreTotem() bool         { return false }
+
+// FreezeImmune checks if the entity is immune to freezing in powder snow. Entities wearing leather armour are
+// immune too, but their frozen state is still tracked.
+func (l *Living) FreezeImmune() bool {
+	return l.freezeImmune
+}
+
+// Frozen returns how far the entity is frozen, ranging from 0 when it is not frozen at all to 1 when it is
+// fully frozen and starts taking freezing damage.
+func (l *Living) Frozen() float64 {
+	return float64(l.frozenTicks) / freezeTicks
+}
+
+// FullyFrozen checks if the entity is fully frozen.
+func (l *Living) FullyFrozen() bool {
+	return l.frozenTicks >= freezeTicks
+}
+
+// SetFrozen sets how far the entity is frozen, ranging from 0 to 1.
+func (l *Living) SetFrozen(frozen float64) {
+	l.setFrozenTicks(int64(min(max(frozen, 0), 1) * freezeTicks))
+}
+
+// setFrozenTicks sets the amount of ticks the entity has been freezing for, updating its viewers if it becomes
+// fully frozen or thaws.
+func (l *Living) setFrozenTicks(ticks int64) {
+	wasFrozen := l.FullyFrozen()
+	l.frozenTicks = min(max(ticks, 0), freezeTicks)
+	if l.FullyFrozen() != wasFrozen {
+		l.updateState()
+	}
+}
+
+// tickFreezing freezes the entity while it is inside powder snow and thaws it when it is not. Fully frozen
+// entities take freezing damage and have HandleFrozen called every tick. Viewers are updated when the entity
+// becomes fully frozen or thaws, although dragonfly does not include the freezing strength in its state.
+func (l *Living) tickFreezing(tx *world.Tx, current int64) {
+	if l.inPowderSnow() && l.canFreeze() {
+		l.setFrozenTicks(l.frozenTicks + 1)
+	} else {
+		l.setFrozenTicks(l.frozenTicks - 2)
+	}
+	if !l.FullyFrozen() {
+		l.fullyFrozenTicks = 0
+		return
+	}
+	l.fullyFrozenTicks++
+
+	ctx := event.C(l)
+	if l.handler.HandleFrozen(ctx, tx, ticksToDuration(l.fullyFrozenTicks)); ctx.Cancelled() || l.closed {
+		return
+	}
+	if current%40 == 0 {
+		l.Hurt(1, FreezingDamageSource{})
+	}
+}
+
+// canFreeze checks if the entity freezes in powder snow. Entities that are immune to freezing or wear leather
+// armour do not freeze.
+func (l *Living) canFreeze() bool {
+	if l.freezeImmune {
+		return false
+	}
+	for _, s := range l.armour.Slots() {
+		if leatherArmour(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// inPowderSnow checks if the bounding box of the entity intersects with a block that freezes it, as decided by
+// Config.FreezingBlock.
+func (l *Living) inPowderSnow() bool {
+	box := l.entityType.BBox(l).Translate(l.Position())
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for y := low[1]; y <= high[1]; y++ {
+		for x := low[0]; x <= high[0]; x++ {
+			for z := low[2]; z <= high[2]; z++ {
+				if l.freezingBlock(l.tx.Block(cube.Pos{x, y, z})) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// PowderSnow is the default Config.FreezingBlock. Dragonfly does not implement powder snow, so it only
+// recognises custom blocks registered with the name "minecraft:powder_snow".
+func PowderSnow(b world.Block) bool {
+	return blockName(b) == "minecraft:powder_snow"
+}
+
+// leatherArmour checks if the item stack passed is a piece of leather armour.
+func leatherArmour(s item.Stack) bool {
+	var tier item.ArmourTier
+	switch it := s.Item().(type) {
+	case item.Helmet:
+		tier = it.Tier
+	case item.Chestplate:
+		tier = it.Tier
+	case item.Leggings:
+		tier = it.Tier
+	case item.Boots:
+		tier = it.Tier
+	}
+	_, ok := tier.(item.ArmourTierLeather)
+	return ok
+}
@@ -0,0 +1,155 @@
+package living
+
+import "slices"
+
+// Control is a flag describing a part of the entity that a Goal takes control of while running. Two goals
+// sharing a Control can never run at the same time.
+type Control uint8
+
+const (
+	// ControlMove is claimed by goals that move the entity around.
+	ControlMove Control = 1 << iota
+	// ControlLook is claimed by goals that change the rotation of the entity.
+	ControlLook
+	// ControlJump is claimed by goals that make the entity jump.
+	ControlJump
+)
+
+// Goal is a single behaviour of a living entity, such as wandering around or following a player. Goals are
+// ticked by the GoalSelector of the entity, which decides which goals may run based on their priority and the
+// Controls they claim.
+// A Goal passed to Config is owned by a single entity and should not be shared between multiple entities if it
+// holds any state.
+type Goal interface {
+	// Controls returns the Controls the Goal claims while running.
+	Controls() Control
+	// CanStart checks if the Goal should start running.
+	CanStart(l *Living) bool
+	// ShouldContinue checks if a running Goal should keep running. If false is returned, the Goal is stopped.
+	ShouldContinue(l *Living) bool
+	// Start is called when the Goal starts running.
+	Start(l *Living)
+	// Tick is called every tick while the Goal is running.
+	Tick(l *Living)
+	// Stop is called when the Goal stops running, either because it finished or because it was interrupted by
+	// a Goal with a higher priority.
+	Stop(l *Living)
+}
+
+// PrioritisedGoal is a Goal with a priority. Goals with a lower priority value take precedence over goals with
+// a higher value.
+type PrioritisedGoal struct {
+	Priority int
+	Goal     Goal
+}
+
+// GoalSelector holds the goals of a living entity and selects which of them are running every tick.
+type GoalSelector struct {
+	goals []*goalEntry
+}
+
+// goalEntry is a PrioritisedGoal registered to a GoalSelector, along with its running state.
+type goalEntry struct {
+	PrioritisedGoal
+	running bool
+}
+
+// NewGoalSelector creates a GoalSelector holding the goals passed.
+func NewGoalSelector(goals ...PrioritisedGoal) *GoalSelector {
+	s := &GoalSelector{}
+	for _, g := range goals {
+		s.Add(g.Priority, g.Goal)
+	}
+	return s
+}
+
+// Add adds a Goal with the priority passed to the GoalSelector.
+func (s *GoalSelector) Add(priority int, g Goal) {
+	s.goals = append(s.goals, &goalEntry{PrioritisedGoal: PrioritisedGoal{Priority: priority, Goal: g}})
+	slices.SortStableFunc(s.goals, func(a, b *goalEntry) int {
+		return a.Priority - b.Priority
+	})
+}
+
+// Remove removes a Goal from the GoalSelector, stopping it if it was running.
+func (s *GoalSelector) Remove(l *Living, g Goal) {
+	s.goals = slices.DeleteFunc(s.goals, func(e *goalEntry) bool {
+		if e.Goal != g {
+			return false
+		}
+		if e.running {
+			e.running = false
+			g.Stop(l)
+		}
+		return true
+	})
+}
+
+// Goals returns all goals registered to the GoalSelector, ordered by priority.
+func (s *GoalSelector) Goals() []PrioritisedGoal {
+	goals := make([]PrioritisedGoal, 0, len(s.goals))
+	for _, e := range s.goals {
+		goals = append(goals, e.PrioritisedGoal)
+	}
+	return goals
+}
+
+// Running returns all goals that are currently running.
+func (s *GoalSelector) Running() []Goal {
+	var goals []Goal
+	for _, e := range s.goals {
+		if e.running {
+			goals = append(goals, e.Goal)
+		}
+	}
+	return goals
+}
+
+// Stop stops all running goals.
+func (s *GoalSelector) Stop(l *Living) {
+	for _, e := range s.goals {
+		if e.running {
+			e.running = false
+			e.Goal.Stop(l)
+		}
+	}
+}
+
+// Tick stops goals that should no longer run, starts goals that are able to run and ticks all running goals.
+func (s *GoalSelector) Tick(l *Living) {
+	for _, e := range s.goals {
+		if e.running && !e.Goal.ShouldContinue(l) {
+			e.running = false
+			e.Goal.Stop(l)
+		}
+	}
+	for _, e := range s.goals {
+		if e.running || !s.available(e) || !e.Goal.CanStart(l) {
+			continue
+		}
+		for _, other := range s.goals {
+			if other.running && other.Goal.Controls()&e.Goal.Controls() != 0 {
+				other.running = false
+				other.Goal.Stop(l)
+			}
+		}
+		e.running = true
+		e.Goal.Start(l)
+	}
+	for _, e := range s.goals {
+		if e.running {
+			e.Goal.Tick(l)
+		}
+	}
+}
+
+// available checks if none of the Controls of the goalEntry passed are claimed by a running goal with an equal
+// or higher priority.
+func (s *GoalSelector) available(e *goalEntry) bool {
+	for _, other := range s.goals {
+		if other.running && other.Priority <= e.Priority && other.Goal.Controls()&e.Goal.Controls() != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package living
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// WanderGoal makes the entity walk to random positions around it every now and then.
+type WanderGoal struct {
+	// Radius is the maximum horizontal distance of the positions the entity wanders to.
+	Radius float64
+	// Chance is the chance per tick that the entity starts wandering. If 0, a chance of 1/120 is used.
+	Chance float64
+
+	ticks int
+}
+
+// Controls ...
+func (*WanderGoal) Controls() Control {
+	// The Navigator turns the entity towards the path it follows, so looking around is claimed as well.
+	return ControlMove | ControlLook
+}
+
+// CanStart ...
+func (w *WanderGoal) CanStart(l *Living) bool {
+	chance := w.Chance
+	if chance == 0 {
+		chance = 1.0 / 120
+	}
+	return l.OnGround() && rand.Float64() < chance
+}
+
+// ShouldContinue ...
+func (w *WanderGoal) ShouldContinue(l *Living) bool {
+	return w.ticks < 200 && l.Navigator().Moving()
+}
+
+// Start ...
+func (w *WanderGoal) Start(l *Living) {
+	angle := rand.Float64() * math.Pi * 2
+	dist := rand.Float64() * w.Radius
+	l.Navigator().MoveTo(l, l.Position().Add(mgl64.Vec3{math.Cos(angle) * dist, 0, math.Sin(angle) * dist}))
+	w.ticks = 0
+}
+
+// Tick ...
+func (w *WanderGoal) Tick(*Living) {
+	w.ticks++
+}
+
+// Stop ...
+func (*WanderGoal) Stop(l *Living) {
+	l.Navigator().Stop()
+}
+
+// LookAtPlayerGoal makes the entity look at the closest player within range.
+type LookAtPlayerGoal struct {
+	// Range is the maximum distance of players that the entity looks at.
+	Range float64
+
+	target *world.EntityHandle
+}
+
+// Controls ...
+func (*LookAtPlayerGoal) Controls() Control {
+	return ControlLook
+}
+
+// CanStart ...
+func (g *LookAtPlayerGoal) CanStart(l *Living) bool {
+	g.target = nil
+	closest := g.Range
+	for p := range l.tx.Players() {
+		if dist := p.Position().Sub(l.Position()).Len(); dist <= closest {
+			closest, g.target = dist, p.H()
+		}
+	}
+	return g.target != nil
+}
+
+// ShouldContinue ...
+func (g *LookAtPlayerGoal) ShouldContinue(l *Living) bool {
+	p, ok := g.target.Entity(l.tx)
+	return ok && p.Position().Sub(l.Position()).Len() <= g.Range
+}
+
+// Start ...
+func (*LookAtPlayerGoal) Start(*Living) {}
+
+// Tick ...
+func (g *LookAtPlayerGoal) Tick(l *Living) {
+	if p, ok := g.target.Entity(l.tx); ok {
+		l.LookAt(p.Position().Add(mgl64.Vec3{0, 1.62}))
+	}
+}
+
+// Stop ...
+func (g *LookAtPlayerGoal) Stop(*Living) {
+	g.target = nil
+}
+
+// horizontalDistance returns the distance between two positions, ignoring the Y axis.
+func horizontalDistance(a, b mgl64.Vec3) float64 {
+	return math.Hypot(a[0]-b[0], a[2]-b[2])
+}
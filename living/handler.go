@@ -1,18 +1,53 @@
 package living
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 	"time"
 )
 
+// Context is the context passed to the methods of a Handler. Handlers receive a *Context, so that calling
+// ctx.Cancel() is seen by the entity that called the handler.
 type Context = event.Context[*Living]
 
+// Handler handles events of a Living entity. NopHandler may be embedded to only implement some of them.
 type Handler interface {
 	// HandleTick handles the entity's tick.
-	HandleTick(ctx Context, tx *world.Tx)
-	// HandleHurt handles the entity being hurt.
-	HandleHurt(ctx Context, damage float64, immune bool, immunity *time.Duration, src world.DamageSource)
+	HandleTick(ctx *Context, tx *world.Tx)
+	// HandleHurt handles the entity being hurt. The damage holds the damage left after each stage of the
+	// damage pipeline, and its Final damage may be modified to change the damage dealt.
+	HandleHurt(ctx *Context, damage *Damage, immune bool, immunity *time.Duration, src world.DamageSource)
+	// HandleEffectAdd handles an effect being added to the entity. The effect may be modified, and ctx.Cancel()
+	// may be called to prevent the effect from being added.
+	HandleEffectAdd(ctx *Context, eff *effect.Effect)
+	// HandleEffectRemove handles an effect being removed from the entity, either because it was removed or
+	// because it expired. ctx.Cancel() may be called to keep an effect that was removed, but cannot prevent an
+	// effect from expiring.
+	HandleEffectRemove(ctx *Context, eff effect.Effect)
+	// HandleDeath handles the entity dying to the damage source passed. The items and experience dropped may be
+	// modified, for example to scale the experience computed from the ExperienceDrop of the entity, and
+	// ctx.Cancel() may be called to prevent the entity from dying.
+	HandleDeath(ctx *Context, src world.DamageSource, drops *[]item.Stack, xp *int)
+	// HandleAttack handles the entity attacking the target passed in melee. The damage dealt and the force and
+	// height of the knock back may be modified, and ctx.Cancel() may be called to cancel the attack.
+	HandleAttack(ctx *Context, target world.Entity, damage, force, height *float64)
+	// HandlePickup handles the entity picking up the item stack passed from the ground. equip is true if the
+	// item is equipped, or false if it is stored in the inventory of the entity. ctx.Cancel() may be called to
+	// leave the item on the ground.
+	HandlePickup(ctx *Context, stack item.Stack, equip *bool)
+	// HandleKnockBack handles the entity being knocked back from the source position passed. The velocity that
+	// the entity is given may be modified, and ctx.Cancel() may be called to prevent the knock back.
+	HandleKnockBack(ctx *Context, src mgl64.Vec3, velocity *mgl64.Vec3)
+	// HandleHeal handles the entity being healed by the healing source passed. The health regained may be
+	// modified, and ctx.Cancel() may be called to prevent the entity from being healed.
+	HandleHeal(ctx *Context, health *float64, src world.HealingSource)
+	// HandleFrozen handles the entity being fully frozen in powder snow. It is called every tick the entity is
+	// fully frozen, with the duration it has been fully frozen for, and may be used to transform the entity, for
+	// example into a stray after 15 seconds. ctx.Cancel() may be called to prevent freezing damage.
+	HandleFrozen(ctx *Context, tx *world.Tx, duration time.Duration)
 }
 
 // NopHandler provides a no-op implementation of the Handler interface.
@@ -20,7 +55,23 @@ type NopHandler struct{}
 
 var _ Handler = NopHandler{}
 
-func (NopHandler) HandleTick(Context, *world.Tx) {}
+func (NopHandler) HandleTick(*Context, *world.Tx) {}
 
-func (NopHandler) HandleHurt(Context, float64, bool, *time.Duration, world.DamageSource) {
+func (NopHandler) HandleHurt(*Context, *Damage, bool, *time.Duration, world.DamageSource) {
 }
+
+func (NopHandler) HandleEffectAdd(*Context, *effect.Effect) {}
+
+func (NopHandler) HandleEffectRemove(*Context, effect.Effect) {}
+
+func (NopHandler) HandleDeath(*Context, world.DamageSource, *[]item.Stack, *int) {}
+
+func (NopHandler) HandleAttack(*Context, world.Entity, *float64, *float64, *float64) {}
+
+func (NopHandler) HandlePickup(*Context, item.Stack, *bool) {}
+
+func (NopHandler) HandleKnockBack(*Context, mgl64.Vec3, *mgl64.Vec3) {}
+
+func (NopHandler) HandleHeal(*Context, *float64, world.HealingSource) {}
+
+func (NopHandler) HandleFrozen(*Context, *world.Tx, time.Duration) {}
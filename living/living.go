@@ -2,18 +2,20 @@ package living
 
 import (
 	"iter"
-	"maps"
 	"math"
 	"slices"
 	"time"
 
+	"github.com/bedrock-gophers/living/living/loot"
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -30,31 +32,83 @@ type Living struct {
 	*livingData
 }
 
-func (l *Living) Heal(health float64, _ world.HealingSource) {
+// Heal heals the entity for the amount of health passed. HandleHeal is called before, which may modify the
+// health regained or cancel the healing. Instant Health hurts undead entities instead.
+func (l *Living) Heal(health float64, src world.HealingSource) {
+	if _, ok := src.(effect.InstantHealingSource); ok && l.undead {
+		// Instant Health deals 1.5 times as much damage to undead entities as it would heal otherwise, which
+		// is equal to the damage dealt by Instant Damage.
+		l.hurt(health*1.5, effect.InstantDamageSource{})
+		return
+	}
+	l.heal(health, src)
+}
+
+// heal heals the entity without inverting Instant Health for undead entities.
+func (l *Living) heal(health float64, src world.HealingSource) {
+	if l.Dead() || l.dying || health <= 0 {
+		return
+	}
+	ctx := event.C(l)
+	if l.handler.HandleHeal(ctx, &health, src); ctx.Cancelled() {
+		return
+	}
 	l.AddHealth(health)
 }
 
+// Hurt hurts the entity for the damage passed, returning the damage dealt and if the entity was vulnerable to
+// it. Instant Damage heals undead entities instead.
 func (l *Living) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
-	if l.Dead() || dmg <= 0 {
+	if _, ok := src.(effect.InstantDamageSource); ok && l.undead {
+		l.heal(dmg/1.5, effect.InstantHealingSource{})
 		return 0, false
 	}
-	totalDamage := dmg
-	damageLeft := totalDamage
+	return l.hurt(dmg, src)
+}
 
-	immune := time.Now().Before(l.immuneUntil)
-	if immune {
-		if damageLeft = damageLeft - l.lastDamage; damageLeft <= 0 {
-			return 0, false
-		}
+// hurt hurts the entity without inverting Instant Damage for undead entities.
+func (l *Living) hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	if l.Dead() || l.dying || dmg <= 0 {
+		return 0, false
+	}
+	if _, ok := l.Effect(effect.FireResistance); (ok || l.fireImmune) && src.Fire() {
+		return 0, false
 	}
+	dmg += l.smiteBonus(src)
+	damage := l.damageFrom(dmg, src)
 
-	immunity := l.immuneDuration
+	immune := l.immuneTicks > 0
+	if immune && damage.Final <= l.lastDamage {
+		return 0, false
+	}
+
+	immunity := ticksToDuration(l.immuneDuration)
 	ctx := event.C[*Living](l)
-	if l.handler.HandleHurt(*ctx, totalDamage, immune, &immunity, src); ctx.Cancelled() {
+	if l.handler.HandleHurt(ctx, &damage, immune, &immunity, src); ctx.Cancelled() {
 		return 0, false
 	}
+	totalDamage, damageLeft := damage.Final, damage.Final
+	if immune {
+		// Only the damage exceeding the damage that made the entity immune is dealt.
+		if damageLeft -= l.lastDamage; damageLeft <= 0 {
+			return 0, false
+		}
+	}
 	l.setAttackImmunity(immunity, totalDamage)
+	if s, ok := src.(entity.AttackDamageSource); ok {
+		if sp, ok := s.Attacker.(interface{ Sprinting() bool }); ok && sp.Sprinting() {
+			// Sprinting attackers knock the entity back further, like the Knockback enchantment would.
+			l.knockBackBonus = enchantment.Knockback.Force(1)
+		}
+	}
+	if a := l.absorption; a > 0 {
+		l.SetAbsorption(a - damageLeft)
+		damageLeft = max(damageLeft-a, 0)
+	}
 	l.AddHealth(-damageLeft)
+	if src.ReducedByArmour() {
+		l.armour.Damage(dmg, l.damageItem)
+	}
 
 	pos := l.Position()
 	for _, viewer := range l.Viewers() {
@@ -72,70 +126,280 @@ func (l *Living) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	return totalDamage, true
 }
 
-func (l *Living) Kill(_ world.DamageSource) {
+// Kill kills the entity, dropping its items and experience. HandleDeath is called before, which may modify the
+// drops and experience or cancel the death, in which case the entity is left with at least half a heart.
+// After being killed, the entity plays its death animation and is removed from the world once its death
+// duration has passed.
+func (l *Living) Kill(src world.DamageSource) {
+	if l.dying {
+		return
+	}
+	attacker, weapon := killer(src)
+	drops, xp := l.dropStacks(attacker, weapon), l.dropExperienceAmount(attacker)
+	ctx := event.C(l)
+	if l.handler.HandleDeath(ctx, src, &drops, &xp); ctx.Cancelled() {
+		if l.Health() < 1 {
+			l.AddHealth(1 - l.Health())
+		}
+		return
+	}
 	for _, viewer := range l.Viewers() {
 		viewer.ViewEntityAction(l, entity.DeathAction{})
 	}
 
 	l.AddHealth(-l.MaxHealth())
-	l.DropItems()
+	l.dropItems(drops)
+	l.dropExperience(xp)
+	l.dying, l.deathTicks = true, 0
+}
 
-	// Wait a little before removing the entity. The client displays a death
-	// animation while the player is dying.
-	time.AfterFunc(time.Millisecond*1100, func() {
-		l.H().ExecWorld(finishDying)
+// Dying checks if the entity was killed and is currently playing its death animation.
+func (l *Living) Dying() bool {
+	return l.dying
+}
+
+// Undead checks if the entity is undead.
+func (l *Living) Undead() bool {
+	return l.undead
+}
+
+// tickDeath advances the death animation of the entity, removing it from the world once the death duration
+// has passed. The client displays a death animation while the entity is dying.
+func (l *Living) tickDeath() {
+	if l.deathTicks++; ticksToDuration(l.deathTicks) >= l.deathDuration {
+		_ = l.Close()
+	}
+}
+
+// DropItems drops the items and experience of the entity at its position. The attacker and weapon passed are
+// the entity that killed the entity and the item it held, if any, which are used to apply Looting and loot
+// table conditions.
+func (l *Living) DropItems(attacker world.Entity, weapon item.Stack) {
+	l.dropItems(l.dropStacks(attacker, weapon))
+	l.dropExperience(l.dropExperienceAmount(attacker))
+}
+
+// dropExperienceAmount returns the amount of experience dropped when the entity is killed by the attacker
+// passed.
+func (l *Living) dropExperienceAmount(attacker world.Entity) int {
+	_, killedByPlayer := attacker.(*player.Player)
+	return l.experience.Amount(killedByPlayer)
+}
+
+// dropStacks returns the item stacks produced by the drops, loot table, equipment and inventory of the entity,
+// leaving out empty stacks and stacks with Curse of Vanishing.
+func (l *Living) dropStacks(attacker world.Entity, weapon item.Stack) []item.Stack {
+	ctx := l.lootContext(attacker, weapon)
+	stacks := l.loot.Generate(ctx)
+	for d := range l.drops {
+		stacks = append(stacks, d.LootingStack(ctx.Looting))
+	}
+	stacks = append(stacks, l.equipmentDrops(ctx.Looting)...)
+	stacks = append(stacks, l.inventory.Items()...)
+	return slices.DeleteFunc(stacks, func(it item.Stack) bool {
+		_, vanishing := it.Enchantment(enchantment.CurseOfVanishing)
+		return it.Empty() || vanishing
 	})
 }
 
-// finishDying completes the death of a player, removing it from the world.
-func finishDying(_ *world.Tx, e world.Entity) {
-	p := e.(entity.Living)
-	_ = p.Close()
+// lootContext returns the loot.Context used to generate loot when the entity is killed by the attacker passed
+// using the weapon passed.
+func (l *Living) lootContext(attacker world.Entity, weapon item.Stack) loot.Context {
+	ctx := loot.Context{
+		Killer:      attacker,
+		Looting:     lootingLevel(weapon),
+		OnFire:      l.OnFireDuration() > 0,
+		Variant:     l.variant,
+		MarkVariant: l.markVariant,
+	}
+	_, ctx.KilledByPlayer = attacker.(*player.Player)
+	return ctx
+}
+
+// killer returns the entity responsible for the damage source passed and the weapon it attacked with. The
+// weapon is only set if the entity was attacked directly and holds an item.
+func killer(src world.DamageSource) (world.Entity, item.Stack) {
+	switch s := src.(type) {
+	case entity.AttackDamageSource:
+		if c, ok := s.Attacker.(item.Carrier); ok {
+			held, _ := c.HeldItems()
+			return s.Attacker, held
+		}
+		return s.Attacker, item.Stack{}
+	case entity.ProjectileDamageSource:
+		return s.Owner, item.Stack{}
+	}
+	return nil, item.Stack{}
 }
 
-func (l *Living) DropItems() {
+// dropItems spawns the item stacks passed at the position of the entity.
+func (l *Living) dropItems(stacks []item.Stack) {
 	pos := l.Position()
-	for d := range l.drops {
-		it := d.Stack()
+	for _, it := range stacks {
 		if it.Empty() {
 			continue
 		}
-		if _, ok := it.Enchantment(enchantment.CurseOfVanishing); ok {
-			continue
-		}
 		opts := world.EntitySpawnOpts{Position: pos}
 		l.tx.AddEntity(entity.NewItem(opts, it))
 	}
 }
 
+// dropExperience spawns experience orbs worth the amount of experience passed at the position of the entity.
+func (l *Living) dropExperience(xp int) {
+	if xp <= 0 {
+		return
+	}
+	for _, orb := range entity.NewExperienceOrbs(l.Position(), xp) {
+		l.tx.AddEntity(orb)
+	}
+}
+
 // setAttackImmunity sets the duration the player is immune to entity attacks.
 func (l *Living) setAttackImmunity(d time.Duration, dmg float64) {
-	l.immuneUntil = time.Now().Add(d)
+	l.immuneTicks = durationToTicks(d)
 	l.lastDamage = dmg
 }
 
+// Attack performs a melee attack on the target passed, hurting and knocking it back. False is returned if the
+// target could not be attacked, because it is out of reach, not living, or because the entity is still waiting
+// for its attack cooldown to pass.
+func (l *Living) Attack(target world.Entity) bool {
+	if l.Dead() || l.dying || l.attackTicks > 0 || !l.canReach(target) {
+		return false
+	}
+	living, ok := target.(entity.Living)
+	if !ok || living.Dead() {
+		return false
+	}
+	dmg, force, height := l.AttackDamage(), 0.45, 0.3608
+	if k, ok := l.mainHand.Enchantment(enchantment.Knockback); ok {
+		inc := enchantment.Knockback.Force(k.Level())
+		force, height = force+inc, height+inc
+	}
+
+	ctx := event.C(l)
+	if l.handler.HandleAttack(ctx, target, &dmg, &force, &height); ctx.Cancelled() {
+		return false
+	}
+	l.attackTicks = l.attackCooldown
+	l.SwingArm()
+
+	n, vulnerable := living.Hurt(dmg, entity.AttackDamageSource{Attacker: l})
+	l.tx.PlaySound(entity.EyePosition(target), sound.Attack{Damage: !mgl64.FloatEqual(n, 0)})
+	if !vulnerable {
+		return true
+	}
+	living.KnockBack(l.Position(), force, height)
+	l.weaponHit(living)
+	return true
+}
+
+// weaponHit applies the effects of the weapon held by the entity after it hit the target passed, setting the
+// target on fire if the weapon has Fire Aspect and using up the durability of the weapon.
+func (l *Living) weaponHit(target entity.Living) {
+	held := l.mainHand
+	if held.Empty() {
+		return
+	}
+	if f, ok := held.Enchantment(enchantment.FireAspect); ok {
+		if flammable, ok := target.(entity.Flammable); ok {
+			flammable.SetOnFire(enchantment.FireAspect.Duration(f.Level()))
+		}
+	}
+	if durable, ok := held.Item().(item.Durable); ok {
+		l.SetHeldItems(l.damageItem(held, durable.DurabilityInfo().AttackDurability), l.offHand)
+	}
+}
+
+// AttackDamage returns the damage dealt by the entity when attacking, taking the held weapon, Sharpness,
+// Strength and Weakness into account.
+func (l *Living) AttackDamage() float64 {
+	dmg := l.attackDamage
+	if weapon, ok := l.mainHand.Item().(item.Weapon); ok {
+		dmg += weapon.AttackDamage()
+	}
+	if s, ok := l.mainHand.Enchantment(enchantment.Sharpness); ok {
+		dmg += enchantment.Sharpness.Addend(s.Level())
+	}
+	if strength, ok := l.Effect(effect.Strength); ok {
+		dmg += dmg * effect.Strength.Multiplier(strength.Level())
+	}
+	if weakness, ok := l.Effect(effect.Weakness); ok {
+		dmg -= dmg * effect.Weakness.Multiplier(weakness.Level())
+	}
+	return max(dmg, 0)
+}
+
+// AttackReady checks if the attack cooldown of the entity has passed, so that it is able to attack again.
+func (l *Living) AttackReady() bool {
+	return l.attackTicks <= 0
+}
+
+// canReach checks if the target passed is within the attack reach of the entity.
+func (l *Living) canReach(target world.Entity) bool {
+	eye := entity.EyePosition(l)
+	box := target.H().Type().BBox(target).Translate(target.Position())
+	low, high := box.Min(), box.Max()
+	closest := mgl64.Vec3{
+		mgl64.Clamp(eye[0], low[0], high[0]),
+		mgl64.Clamp(eye[1], low[1], high[1]),
+		mgl64.Clamp(eye[2], low[2], high[2]),
+	}
+	return closest.Sub(eye).Len() <= l.attackReach
+}
+
+// SwingArm makes the entity swing its arm.
+func (l *Living) SwingArm() {
+	for _, v := range l.Viewers() {
+		v.ViewEntityAction(l, entity.SwingArmAction{})
+	}
+}
+
 // KnockBack knocks the player back with a given force and height. A source is passed which indicates the
 // source of the velocity, typically the position of an attacking entity. The source is used to calculate the
 // direction which the entity should be knocked back in.
+// The force and height are reduced by the knock back resistance of the entity, and the force is increased if
+// the entity was just hurt by a sprinting attacker.
 func (l *Living) KnockBack(src mgl64.Vec3, force, height float64) {
-	if l.Dead() {
+	if l.Dead() || l.dying {
+		return
+	}
+	force += l.knockBackBonus
+	l.knockBackBonus = 0
+
+	velocity := l.knockBack(src, force, height)
+	ctx := event.C(l)
+	if l.handler.HandleKnockBack(ctx, src, &velocity); ctx.Cancelled() {
 		return
 	}
-	l.knockBack(src, force, height)
+	l.SetVelocity(velocity)
 }
 
-// knockBack is an unexported function that is used to knock the player back. This function does not check if the player
-// can take damage or not.
-func (l *Living) knockBack(src mgl64.Vec3, force, height float64) {
-	velocity := l.Position().Sub(src)
-	velocity[1] = 0
+// knockBack computes the velocity of the entity after being knocked back from the source passed. Like in
+// vanilla, half of the current velocity of the entity is kept, and it is only knocked upwards when on the
+// ground.
+func (l *Living) knockBack(src mgl64.Vec3, force, height float64) mgl64.Vec3 {
+	resistance := l.KnockBackResistance()
+	force, height = force*(1-resistance), height*(1-resistance)
+
+	dir := l.Position().Sub(src)
+	dir[1] = 0
+	if dir.Len() != 0 {
+		dir = dir.Normalize().Mul(force)
+	}
 
-	if velocity.Len() != 0 {
-		velocity = velocity.Normalize().Mul(force)
+	vel := l.Velocity()
+	velocity := mgl64.Vec3{vel[0]/2 + dir[0], vel[1], vel[2]/2 + dir[2]}
+	if l.OnGround() {
+		velocity[1] = min(0.4, vel[1]/2+height)
 	}
-	velocity[1] = height
+	return velocity
+}
 
-	l.SetVelocity(velocity.Mul(1))
+// KnockBackResistance returns the knock back resistance of the entity, ranging from 0 to 1, including the knock
+// back resistance of the armour it wears. A resistance of 1 prevents the entity from being knocked back.
+func (l *Living) KnockBackResistance() float64 {
+	return min(l.knockBackResistance+l.armour.KnockBackResistance(), 1)
 }
 
 // Tx returns the transaction.
@@ -176,27 +440,104 @@ func (l *Living) Drops() iter.Seq[Drop] {
 	return l.drops
 }
 
-// AddEffect adds an effect to the entity.
+// AddEffect adds an effect to the entity. If the effect is instant, it is applied immediately. If not, it is
+// applied every tick until it expires. An existing effect of the same type is only overwritten if the new
+// effect has a higher level, or an equal level and a longer duration.
 func (l *Living) AddEffect(e effect.Effect) {
-	l.effects[e.Type()] = e
+	ctx := event.C(l)
+	if l.handler.HandleEffectAdd(ctx, &e); ctx.Cancelled() {
+		return
+	}
+	l.flushEffects()
+	l.effects.Add(e, l)
+	l.updateState()
 }
 
 // RemoveEffect removes the effect of an entity.
 func (l *Living) RemoveEffect(e effect.Type) {
-	delete(l.effects, e)
+	l.flushEffects()
+	eff, ok := l.effects.Effect(e)
+	if !ok {
+		return
+	}
+	ctx := event.C(l)
+	if l.handler.HandleEffectRemove(ctx, eff); ctx.Cancelled() {
+		return
+	}
+	l.effects.Remove(e, l)
+	l.updateState()
+}
+
+// Effect returns the effect of the type passed and true if the entity has it.
+func (l *Living) Effect(e effect.Type) (effect.Effect, bool) {
+	l.flushEffects()
+	return l.effects.Effect(e)
+}
+
+// Goals returns the GoalSelector of the entity.
+func (l *Living) Goals() *GoalSelector {
+	return l.goals
+}
+
+// Navigator returns the Navigator used to move the entity along paths.
+func (l *Living) Navigator() *Navigator {
+	return l.navigator
 }
 
 // Effects returns the effects of an entity.
 func (l *Living) Effects() []effect.Effect {
-	return slices.Collect(maps.Values(l.effects))
+	l.flushEffects()
+	return l.effects.Effects()
+}
+
+// flushEffects adds the effects the entity was loaded with to its effects. Effects such as Absorption reset
+// the state of the entity when added, so the absorption health it was saved with is restored afterwards.
+func (l *Living) flushEffects() {
+	if len(l.loadedEffects) == 0 {
+		return
+	}
+	absorption := l.absorption
+	for _, e := range l.loadedEffects {
+		l.effects.Add(e, l)
+	}
+	l.loadedEffects, l.absorption = nil, absorption
 }
 
-// Close closes the entity.
+// Close closes the entity, removing it from the world. Calling Close on an entity that was already closed has
+// no effect.
 func (l *Living) Close() error {
+	if l.closed {
+		return nil
+	}
+	l.closed = true
 	l.tx.RemoveEntity(l)
+	_ = l.handle.Close()
 	return nil
 }
 
+// Transform replaces the entity with a new entity created using the Config passed, keeping its position,
+// rotation, velocity, name tag and the items it has equipped, like skeletons turning into strays. The new entity is returned.
+func (l *Living) Transform(conf Config) world.Entity {
+	opts := world.EntitySpawnOpts{
+		Position: l.Position(),
+		Rotation: l.Rotation(),
+		Velocity: l.Velocity(),
+		NameTag:  l.NameTag(),
+	}
+	e := l.tx.AddEntity(opts.New(conf.EntityType, conf))
+	if nl, ok := e.(*Living); ok {
+		for slot := SlotHelmet; slot <= SlotOffHand; slot++ {
+			// Empty slots are left alone, so that the new entity keeps the equipment set in its Config.
+			if it := l.Equipped(slot); !it.Empty() {
+				_ = nl.Equip(slot, it)
+				nl.SetDropChance(slot, l.dropChances[slot])
+			}
+		}
+	}
+	_ = l.Close()
+	return e
+}
+
 // H returns the EntityHandle.
 func (l *Living) H() *world.EntityHandle {
 	return l.handle
@@ -250,9 +591,16 @@ func (l *Living) Invisible() bool {
 	return l.invisible
 }
 
-// SetInvisible ...
-func (l *Living) SetInvisible(invisible bool) {
-	l.invisible = invisible
+// SetInvisible makes the entity invisible. SetInvisible and SetVisible are called by the Invisibility effect
+// when it is added to or removed from the entity.
+func (l *Living) SetInvisible() {
+	l.invisible = true
+	l.updateState()
+}
+
+// SetVisible makes the entity visible again after being invisible.
+func (l *Living) SetVisible() {
+	l.invisible = false
 	l.updateState()
 }
 
@@ -287,7 +635,7 @@ func (l *Living) SetNameTag(s string) {
 // position of the player.
 // Move also rotates the player, adding deltaYaw and deltaPitch to the respective values.
 func (l *Living) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
-	if l.Dead() || (deltaPos.ApproxEqual(mgl64.Vec3{}) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0)) {
+	if l.Dead() || l.dying || (deltaPos.ApproxEqual(mgl64.Vec3{}) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0)) {
 		return
 	}
 	if l.immobile {
@@ -327,6 +675,8 @@ func (l *Living) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 }
 
 // MoveToTarget Target is assumed to be another Entity or similar struct with position getters.
+// MoveToTarget moves in a straight line towards the target. Navigator should be used to find a way around
+// obstacles.
 func (l *Living) MoveToTarget(target mgl64.Vec3, jumpVelocity float64) {
 	if l.Dead() {
 		return
@@ -432,6 +782,9 @@ func (l *Living) OnFireDuration() time.Duration {
 
 // SetOnFire ...
 func (l *Living) SetOnFire(duration time.Duration) {
+	if l.fireImmune && duration > 0 {
+		return
+	}
 	ticks := int64(duration.Seconds() * 20)
 	l.fireTicks = ticks
 	l.updateState()
@@ -442,24 +795,25 @@ func (l *Living) Extinguish() {
 	l.SetOnFire(0)
 }
 
-// ImmuneUntil ...
-func (l *Living) ImmuneUntil() time.Time {
-	return l.immuneUntil
+// AttackImmunity returns the remaining duration that the entity is immune to attacks.
+func (l *Living) AttackImmunity() time.Duration {
+	return ticksToDuration(l.immuneTicks)
 }
 
-// SetImmuneDuration ...
+// SetImmuneDuration sets the duration the entity is immune to attacks after being hurt. The duration is
+// rounded down to whole ticks.
 func (l *Living) SetImmuneDuration(duration time.Duration) {
-	l.immuneDuration = duration
+	l.immuneDuration = durationToTicks(duration)
 }
 
-// ImmuneDuration ...
+// ImmuneDuration returns the duration the entity is immune to attacks after being hurt.
 func (l *Living) ImmuneDuration() time.Duration {
-	return l.immuneDuration
+	return ticksToDuration(l.immuneDuration)
 }
 
 // AttackImmune ...
 func (l *Living) AttackImmune() bool {
-	return l.ImmuneUntil().After(time.Now())
+	return l.immuneTicks > 0
 }
 
 // LastDamage ...
@@ -469,11 +823,39 @@ func (l *Living) LastDamage() float64 {
 
 // Tick ticks the entity, performing actions such as checking if the player is still breaking a block.
 func (l *Living) Tick(tx *world.Tx, current int64) {
+	if l.dying {
+		l.tickDeath()
+		return
+	}
 	l.age += 50 * time.Millisecond
+	if l.immuneTicks > 0 {
+		l.immuneTicks--
+	}
+	if l.attackTicks > 0 {
+		l.attackTicks--
+	}
+	l.knockBackBonus = 0
 	ctx := event.C(l)
-	l.handler.HandleTick(*ctx, tx)
+	l.handler.HandleTick(ctx, tx)
+
+	// The entity may be closed by any of the handlers called during the tick, for example after being
+	// transformed, after which it must not be ticked any further.
+	if ctx.Cancelled() || l.Dead() || l.closed {
+		return
+	}
+	if l.regenerationInterval > 0 && l.Age()%ticksToDuration(l.regenerationInterval) == 0 && l.Health() < l.MaxHealth() {
+		l.Heal(1, PassiveHealingSource{})
+	}
+
+	l.tickEffects(tx)
+	if l.Dead() || l.closed {
+		return
+	}
 
-	if ctx.Cancelled() || l.Dead() {
+	l.tickAirSupply()
+	l.tickSuffocation(current)
+	l.tickFreezing(tx, current)
+	if l.Dead() || l.closed {
 		return
 	}
 
@@ -481,6 +863,8 @@ func (l *Living) Tick(tx *world.Tx, current int64) {
 		l.Hurt(4, entity.VoidDamageSource{})
 	}
 
+	l.tickSunlight(tx)
+
 	if l.OnFireDuration() > 0 {
 		l.fireTicks -= 1
 		if l.OnFireDuration() <= 0 || tx.RainingAt(cube.PosFromVec3(l.Position())) {
@@ -490,14 +874,43 @@ func (l *Living) Tick(tx *world.Tx, current int64) {
 			l.Hurt(1, block.FireDamageSource{})
 		}
 	}
+	if l.Dead() || l.closed {
+		return
+	}
 
+	l.updateArmour()
+	l.tickPickup(tx)
 	l.onGround = l.checkOnGround()
+	l.goals.Tick(l)
+	l.navigator.tick(l)
+	if l.closed {
+		return
+	}
 
 	m := l.mc.TickMovement(l, l.Position(), l.Velocity(), l.Rotation(), tx)
 	m.Send()
 
 	l.data.Vel = m.Velocity()
-	l.Move(m.Position().Sub(l.Position()), 0, 0)
+	before := l.Position()
+	l.Move(m.Position().Sub(before), 0, 0)
+
+	l.checkEntityInsiders(l.entityType.BBox(l).Translate(l.Position()))
+	if l.closed {
+		return
+	}
+	l.tickBlockContact(current, l.Position().Sub(before))
+}
+
+// tickEffects applies the effects of the entity and removes the ones that expired.
+func (l *Living) tickEffects(tx *world.Tx) {
+	l.flushEffects()
+	before := l.effects.Effects()
+	l.effects.Tick(l, tx)
+	for _, eff := range before {
+		if _, ok := l.effects.Effect(eff.Type()); !ok {
+			l.handler.HandleEffectRemove(event.C(l), eff)
+		}
+	}
 }
 
 // Variant ...
@@ -560,8 +973,6 @@ func (l *Living) calculateCollisionAdjustedMovement(vel mgl64.Vec3) mgl64.Vec3 {
 	entityBBox := l.entityType.BBox(l).Translate(l.Position())
 	deltaX, deltaY, deltaZ := vel[0], vel[1], vel[2]
 
-	l.checkEntityInsiders(entityBBox)
-
 	// Extend the bounding box by the movement vector to get collision area
 	grown := entityBBox.Extend(vel).Grow(0.001)
 	low, high := grown.Min(), grown.Max()
@@ -635,6 +1046,10 @@ func (l *Living) checkEntityInsiders(entityBBox cube.BBox) {
 			for z := low[2]; z <= high[2]; z++ {
 				blockPos := cube.Pos{x, y, z}
 				b := l.tx.Block(blockPos)
+				if _, ok := b.(block.Cactus); ok {
+					// Contact with cactus is handled by tickBlockContact.
+					continue
+				}
 				if collide, ok := b.(block.EntityInsider); ok {
 					collide.EntityInside(blockPos, l.tx, l)
 					if _, liquid := b.(world.Liquid); liquid {
@@ -712,6 +1127,72 @@ func (l *Living) insideOfSolid() bool {
 	return false
 }
 
+// tickSuffocation hurts the entity if its eyes are inside a solid block, pushing it out of the block if it was
+// configured to.
+func (l *Living) tickSuffocation(current int64) {
+	if l.ignoresSuffocation || !l.insideOfSolid() {
+		return
+	}
+	if l.pushOutOfBlocks {
+		l.pushOutOfBlock()
+	}
+	if current%10 == 0 {
+		l.Hurt(1, entity.SuffocationDamageSource{})
+	}
+}
+
+// pushOutOfBlock gives the entity a small velocity towards the closest side of the block its eyes are in that
+// is not blocked by another solid block.
+func (l *Living) pushOutOfBlock() {
+	eye := entity.EyePosition(l)
+	pos := cube.PosFromVec3(eye)
+	rel := eye.Sub(pos.Vec3())
+
+	best, dist := cube.Face(-1), math.MaxFloat64
+	for _, face := range []cube.Face{cube.FaceUp, cube.FaceNorth, cube.FaceSouth, cube.FaceWest, cube.FaceEast} {
+		if _, solid := l.tx.Block(pos.Side(face)).Model().(model.Solid); solid {
+			continue
+		}
+		var d float64
+		switch face {
+		case cube.FaceUp:
+			d = 1 - rel[1]
+		case cube.FaceNorth:
+			d = rel[2]
+		case cube.FaceSouth:
+			d = 1 - rel[2]
+		case cube.FaceWest:
+			d = rel[0]
+		case cube.FaceEast:
+			d = 1 - rel[0]
+		}
+		if d < dist {
+			best, dist = face, d
+		}
+	}
+	if best == -1 {
+		return
+	}
+	vel := l.Velocity()
+	if best == cube.FaceUp {
+		vel[1] = 0.1
+	} else {
+		dir := cube.Pos{}.Side(best).Vec3()
+		vel[0], vel[2] = dir[0]*0.1, dir[2]*0.1
+	}
+	l.data.Vel = vel
+}
+
+// durationToTicks converts a duration to a number of ticks, rounding down.
+func durationToTicks(d time.Duration) int64 {
+	return int64(d / (time.Second / 20))
+}
+
+// ticksToDuration converts a number of ticks to a duration.
+func ticksToDuration(ticks int64) time.Duration {
+	return time.Duration(ticks) * time.Second / 20
+}
+
 // updateState updates the state of the player to all Viewers of the player.
 func (l *Living) updateState() {
 	for _, v := range l.Viewers() {
@@ -0,0 +1,76 @@
+package loot
+
+import "math/rand/v2"
+
+// Condition is a condition that must be satisfied for a Pool to be rolled or an Entry to be selected.
+type Condition interface {
+	// Satisfied checks if the Condition is satisfied for the Context passed.
+	Satisfied(ctx Context) bool
+}
+
+// KilledByPlayer is satisfied if the entity was killed by a player.
+type KilledByPlayer struct{}
+
+// Satisfied ...
+func (KilledByPlayer) Satisfied(ctx Context) bool {
+	return ctx.KilledByPlayer
+}
+
+// RandomChance is satisfied with a fixed chance between 0 and 1.
+type RandomChance struct {
+	Chance float64
+}
+
+// Satisfied ...
+func (c RandomChance) Satisfied(Context) bool {
+	return rand.Float64() < c.Chance
+}
+
+// RandomChanceWithLooting is satisfied with a chance that increases by LootingMultiplier for every level of
+// looting used to kill the entity.
+type RandomChanceWithLooting struct {
+	Chance, LootingMultiplier float64
+}
+
+// Satisfied ...
+func (c RandomChanceWithLooting) Satisfied(ctx Context) bool {
+	return rand.Float64() < c.Chance+float64(ctx.Looting)*c.LootingMultiplier
+}
+
+// OnFire is satisfied if the entity was on fire when it died.
+type OnFire struct{}
+
+// Satisfied ...
+func (OnFire) Satisfied(ctx Context) bool {
+	return ctx.OnFire
+}
+
+// HasVariant is satisfied if the entity has the variant passed.
+type HasVariant struct {
+	Variant int32
+}
+
+// Satisfied ...
+func (c HasVariant) Satisfied(ctx Context) bool {
+	return ctx.Variant == c.Variant
+}
+
+// HasMarkVariant is satisfied if the entity has the mark variant passed.
+type HasMarkVariant struct {
+	MarkVariant int32
+}
+
+// Satisfied ...
+func (c HasMarkVariant) Satisfied(ctx Context) bool {
+	return ctx.MarkVariant == c.MarkVariant
+}
+
+// Not inverts the Condition it holds.
+type Not struct {
+	Condition Condition
+}
+
+// Satisfied ...
+func (c Not) Satisfied(ctx Context) bool {
+	return !c.Condition.Satisfied(ctx)
+}
@@ -0,0 +1,123 @@
+package loot
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Function modifies the item stack produced by an Entry.
+type Function interface {
+	// Apply applies the Function to the item stack passed and returns the resulting stack. The stack passed may
+	// have a count of 0, in which case it is empty but still holds its item.
+	Apply(s item.Stack, ctx Context) item.Stack
+}
+
+// SetCount sets the count of the stack to a random number in the Range.
+type SetCount struct {
+	Count Range
+}
+
+// Apply ...
+func (f SetCount) Apply(s item.Stack, _ Context) item.Stack {
+	return s.Grow(f.Count.Roll() - s.Count())
+}
+
+// LootingEnchant adds a random number in the Range to the count of the stack for every level of looting used
+// to kill the entity. If Limit is above 0, the count of the stack is never increased above it.
+type LootingEnchant struct {
+	Count Range
+	Limit int
+}
+
+// Apply ...
+func (f LootingEnchant) Apply(s item.Stack, ctx Context) item.Stack {
+	if ctx.Looting <= 0 {
+		return s
+	}
+	bonus := 0
+	for range ctx.Looting {
+		bonus += f.Count.Roll()
+	}
+	s = s.Grow(bonus)
+	if f.Limit > 0 && s.Count() > f.Limit {
+		s = s.Grow(f.Limit - s.Count())
+	}
+	return s
+}
+
+// EnchantRandomly adds a random enchantment with a random level to the stack. The enchantment is selected from
+// Enchantments, or from all registered enchantments if empty. Only enchantments compatible with the item are
+// selected.
+type EnchantRandomly struct {
+	Enchantments []item.EnchantmentType
+}
+
+// Apply ...
+func (f EnchantRandomly) Apply(s item.Stack, _ Context) item.Stack {
+	if s.Empty() {
+		return s
+	}
+	types := f.Enchantments
+	if len(types) == 0 {
+		types = item.Enchantments()
+	}
+	var compatible []item.EnchantmentType
+	for _, t := range types {
+		if t.CompatibleWithItem(s.Item()) {
+			compatible = append(compatible, t)
+		}
+	}
+	if len(compatible) == 0 {
+		return s
+	}
+	t := compatible[rand.IntN(len(compatible))]
+	return s.WithEnchantments(item.NewEnchantment(t, 1+rand.IntN(t.MaxLevel())))
+}
+
+// FurnaceSmelt replaces the item of the stack with its smelted product if the entity was on fire when it died.
+type FurnaceSmelt struct{}
+
+// Apply ...
+func (FurnaceSmelt) Apply(s item.Stack, ctx Context) item.Stack {
+	// Stacks with a count of 0 are smelted too, as functions applied after this one may still increase their
+	// count.
+	if !ctx.OnFire || s.Item() == nil {
+		return s
+	}
+	sm, ok := s.Item().(item.Smeltable)
+	if !ok || sm.SmeltInfo().Product.Empty() {
+		return s
+	}
+	return item.NewStack(sm.SmeltInfo().Product.Item(), s.Count())
+}
+
+// SetDamage sets the durability of the stack to a random fraction of its maximum durability between Min and
+// Max, which are values between 0 and 1.
+type SetDamage struct {
+	Min, Max float64
+}
+
+// Apply ...
+func (f SetDamage) Apply(s item.Stack, _ Context) item.Stack {
+	if _, ok := s.Item().(item.Durable); !ok {
+		return s
+	}
+	frac := f.Min + rand.Float64()*(f.Max-f.Min)
+	return s.WithDurability(max(int(math.Round(frac*float64(s.MaxDurability()))), 1))
+}
+
+// Conditional applies the Function it holds only if all of its conditions are satisfied.
+type Conditional struct {
+	Conditions []Condition
+	Function   Function
+}
+
+// Apply ...
+func (f Conditional) Apply(s item.Stack, ctx Context) item.Stack {
+	if !satisfied(f.Conditions, ctx) {
+		return s
+	}
+	return f.Function.Apply(s, ctx)
+}
@@ -0,0 +1,217 @@
+package loot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Load reads a loot table in the JSON format used by vanilla Minecraft from the file at the path passed.
+func Load(path string) (Table, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Table{}, fmt.Errorf("load loot table: %w", err)
+	}
+	return Parse(b)
+}
+
+// Parse parses a loot table in the JSON format used by vanilla Minecraft. An error is returned if the table
+// contains items, conditions or functions that are not supported.
+func Parse(b []byte) (Table, error) {
+	var t Table
+	if err := json.Unmarshal(b, &t); err != nil {
+		return Table{}, fmt.Errorf("parse loot table: %w", err)
+	}
+	return t, nil
+}
+
+// UnmarshalJSON ...
+func (t *Table) UnmarshalJSON(b []byte) error {
+	var data struct {
+		Pools []jsonPool `json:"pools"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	t.Pools = make([]Pool, 0, len(data.Pools))
+	for i, p := range data.Pools {
+		pool, err := p.pool()
+		if err != nil {
+			return fmt.Errorf("pool %v: %w", i, err)
+		}
+		t.Pools = append(t.Pools, pool)
+	}
+	return nil
+}
+
+type jsonPool struct {
+	Rolls      jsonRange         `json:"rolls"`
+	Entries    []jsonEntry       `json:"entries"`
+	Conditions []json.RawMessage `json:"conditions"`
+}
+
+func (p jsonPool) pool() (Pool, error) {
+	conditions, err := parseConditions(p.Conditions)
+	if err != nil {
+		return Pool{}, err
+	}
+	pool := Pool{Rolls: Range{Min: int(p.Rolls.Min), Max: int(p.Rolls.Max)}, Conditions: conditions}
+	for i, e := range p.Entries {
+		entry, err := e.entry()
+		if err != nil {
+			return Pool{}, fmt.Errorf("entry %v: %w", i, err)
+		}
+		pool.Entries = append(pool.Entries, entry)
+	}
+	return pool, nil
+}
+
+type jsonEntry struct {
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Weight     int               `json:"weight"`
+	Conditions []json.RawMessage `json:"conditions"`
+	Functions  []json.RawMessage `json:"functions"`
+}
+
+func (e jsonEntry) entry() (Entry, error) {
+	conditions, err := parseConditions(e.Conditions)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{Weight: e.Weight, Conditions: conditions}
+	if e.Type == "empty" {
+		return entry, nil
+	}
+	if e.Type != "item" {
+		return Entry{}, fmt.Errorf("unsupported entry type %q", e.Type)
+	}
+
+	var meta int16
+	for _, raw := range e.Functions {
+		var f struct {
+			Function string `json:"function"`
+			Data     int16  `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return Entry{}, err
+		}
+		if f.Function == "set_data" {
+			// The data value of an item is part of its type in Dragonfly, so it is resolved right away.
+			meta = f.Data
+			continue
+		}
+		fn, err := parseFunction(f.Function, raw)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.Functions = append(entry.Functions, fn)
+	}
+	it, ok := world.ItemByName(e.Name, meta)
+	if !ok {
+		return Entry{}, fmt.Errorf("unknown item %v:%v", e.Name, meta)
+	}
+	entry.Item = it
+	return entry, nil
+}
+
+// parseFunction parses the JSON of a function with the name passed.
+func parseFunction(name string, raw json.RawMessage) (Function, error) {
+	var data struct {
+		Count      jsonRange         `json:"count"`
+		Limit      int               `json:"limit"`
+		Damage     jsonRange         `json:"damage"`
+		Conditions []json.RawMessage `json:"conditions"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	var f Function
+	switch name {
+	case "set_count":
+		f = SetCount{Count: Range{Min: int(data.Count.Min), Max: int(data.Count.Max)}}
+	case "looting_enchant":
+		f = LootingEnchant{Count: Range{Min: int(data.Count.Min), Max: int(data.Count.Max)}, Limit: data.Limit}
+	case "enchant_randomly":
+		f = EnchantRandomly{}
+	case "furnace_smelt":
+		f = FurnaceSmelt{}
+	case "set_damage":
+		f = SetDamage{Min: data.Damage.Min, Max: data.Damage.Max}
+	default:
+		return nil, fmt.Errorf("unsupported function %q", name)
+	}
+	conditions, err := parseConditions(data.Conditions)
+	if err != nil || len(conditions) == 0 {
+		return f, err
+	}
+	return Conditional{Conditions: conditions, Function: f}, nil
+}
+
+// parseConditions parses a list of JSON conditions.
+func parseConditions(list []json.RawMessage) ([]Condition, error) {
+	conditions := make([]Condition, 0, len(list))
+	for _, raw := range list {
+		var data struct {
+			Condition         string  `json:"condition"`
+			Chance            float64 `json:"chance"`
+			LootingMultiplier float64 `json:"looting_multiplier"`
+			Value             int32   `json:"value"`
+			Properties        struct {
+				OnFire *bool `json:"on_fire"`
+			} `json:"properties"`
+		}
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		switch data.Condition {
+		case "killed_by_player", "killed_by_player_or_pets":
+			conditions = append(conditions, KilledByPlayer{})
+		case "random_chance":
+			conditions = append(conditions, RandomChance{Chance: data.Chance})
+		case "random_chance_with_looting":
+			conditions = append(conditions, RandomChanceWithLooting{Chance: data.Chance, LootingMultiplier: data.LootingMultiplier})
+		case "entity_properties":
+			if onFire := data.Properties.OnFire; onFire != nil {
+				if *onFire {
+					conditions = append(conditions, OnFire{})
+				} else {
+					conditions = append(conditions, Not{Condition: OnFire{}})
+				}
+			}
+		case "has_variant":
+			conditions = append(conditions, HasVariant{Variant: data.Value})
+		case "has_mark_variant":
+			conditions = append(conditions, HasMarkVariant{MarkVariant: data.Value})
+		default:
+			return nil, fmt.Errorf("unsupported condition %q", data.Condition)
+		}
+	}
+	return conditions, nil
+}
+
+// jsonRange is a range in a loot table, which may either be a single number or an object with a minimum and
+// maximum value.
+type jsonRange struct {
+	Min, Max float64
+}
+
+// UnmarshalJSON ...
+func (r *jsonRange) UnmarshalJSON(b []byte) error {
+	var n float64
+	if err := json.Unmarshal(b, &n); err == nil {
+		r.Min, r.Max = n, n
+		return nil
+	}
+	var data struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	r.Min, r.Max = data.Min, data.Max
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package loot implements loot tables modelled on those of vanilla Minecraft. A Table consists of pools, each
+// of which rolls a number of weighted entries. Conditions decide if pools and entries may be rolled, and
+// functions modify the item stacks produced.
+package loot
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Context holds information on the death of the entity that loot is generated for. It is passed to every
+// Condition and Function.
+type Context struct {
+	// Killer is the entity that killed the entity dropping the loot. It is nil if the entity was not killed
+	// by another entity.
+	Killer world.Entity
+	// KilledByPlayer is true if the Killer is a player.
+	KilledByPlayer bool
+	// Looting is the level of the looting enchantment on the weapon used to kill the entity.
+	Looting int
+	// OnFire is true if the entity was on fire when it died.
+	OnFire bool
+	// Variant and MarkVariant are the variant and mark variant of the entity.
+	Variant, MarkVariant int32
+}
+
+// Table is a loot table, consisting of pools that are all rolled when loot is generated.
+type Table struct {
+	Pools []Pool
+}
+
+// Generate generates the loot of the Table using the Context passed. Empty stacks are never returned.
+func (t Table) Generate(ctx Context) []item.Stack {
+	var stacks []item.Stack
+	for _, p := range t.Pools {
+		stacks = append(stacks, p.Generate(ctx)...)
+	}
+	return stacks
+}
+
+// Pool is a set of weighted entries that is rolled a number of times. Every roll selects one of the entries.
+type Pool struct {
+	// Rolls is the number of times the Pool is rolled.
+	Rolls Range
+	// Entries are the entries that may be selected by a roll.
+	Entries []Entry
+	// Conditions must all be satisfied for the Pool to be rolled at all.
+	Conditions []Condition
+}
+
+// Generate rolls the Pool using the Context passed and returns the resulting item stacks.
+func (p Pool) Generate(ctx Context) []item.Stack {
+	if !satisfied(p.Conditions, ctx) {
+		return nil
+	}
+	var stacks []item.Stack
+	for range p.Rolls.Roll() {
+		e, ok := p.pick(ctx)
+		if !ok || e.Item == nil {
+			continue
+		}
+		s := item.NewStack(e.Item, 1)
+		for _, f := range e.Functions {
+			s = f.Apply(s, ctx)
+		}
+		if !s.Empty() {
+			stacks = append(stacks, s)
+		}
+	}
+	return stacks
+}
+
+// pick selects a random Entry from the Pool, taking the weights of the entries into account. Only entries
+// with all their conditions satisfied may be selected.
+func (p Pool) pick(ctx Context) (Entry, bool) {
+	entries, total := make([]Entry, 0, len(p.Entries)), 0
+	for _, e := range p.Entries {
+		if satisfied(e.Conditions, ctx) {
+			entries, total = append(entries, e), total+e.weight()
+		}
+	}
+	if total == 0 {
+		return Entry{}, false
+	}
+	n := rand.IntN(total)
+	for _, e := range entries {
+		if n -= e.weight(); n < 0 {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Entry is an entry of a Pool that produces an item stack when selected.
+type Entry struct {
+	// Item is the item produced by the Entry. If nil, the Entry is empty and produces nothing when selected.
+	Item world.Item
+	// Weight is the weight of the Entry. Entries with a higher weight are more likely to be selected. If 0, a
+	// weight of 1 is used.
+	Weight int
+	// Conditions must all be satisfied for the Entry to be selected.
+	Conditions []Condition
+	// Functions are applied in order to the item stack produced by the Entry. The stack initially has a count
+	// of 1.
+	Functions []Function
+}
+
+// weight returns the weight of the Entry, defaulting to 1.
+func (e Entry) weight() int {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// Range is an inclusive range of integers.
+type Range struct {
+	Min, Max int
+}
+
+// Exactly returns a Range that always rolls n.
+func Exactly(n int) Range {
+	return Range{Min: n, Max: n}
+}
+
+// Roll returns a random number between Min and Max, both inclusive.
+func (r Range) Roll() int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rand.IntN(r.Max-r.Min+1)
+}
+
+// satisfied checks if all conditions passed are satisfied.
+func satisfied(conditions []Condition, ctx Context) bool {
+	for _, c := range conditions {
+		if !c.Satisfied(ctx) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package living
+
+import (
+	"math"
+
+	"github.com/bedrock-gophers/living/living/path"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NavigationStatus is the status of a Navigator following a path.
+type NavigationStatus uint8
+
+const (
+	// NavigationIdle is the status of a Navigator that was never given a destination or was stopped.
+	NavigationIdle NavigationStatus = iota
+	// NavigationMoving is the status of a Navigator that is currently following a path.
+	NavigationMoving
+	// NavigationCompleted is the status of a Navigator that reached its destination.
+	NavigationCompleted
+	// NavigationFailed is the status of a Navigator that was unable to reach its destination.
+	NavigationFailed
+)
+
+// Navigator computes paths for a living entity and makes it follow them tick by tick. If the entity gets
+// stuck while following a path, the path is recomputed.
+type Navigator struct {
+	conf   path.Config
+	path   *path.Path
+	target mgl64.Vec3
+	status NavigationStatus
+
+	ticks     int
+	retries   int
+	lastCheck mgl64.Vec3
+}
+
+// NewNavigator creates a Navigator computing paths using the path.Config passed. If the BBox of the config is
+// empty, the BBox of the entity type is used.
+func NewNavigator(conf path.Config) *Navigator {
+	return &Navigator{conf: conf}
+}
+
+// MoveTo computes a path to the target passed and starts following it. False is returned if no path getting
+// closer to the target could be found, in which case the status of the Navigator is set to NavigationFailed.
+func (n *Navigator) MoveTo(l *Living, target mgl64.Vec3) bool {
+	n.target, n.retries, n.ticks = target, 0, 0
+	return n.compute(l)
+}
+
+// Stop stops following the current path.
+func (n *Navigator) Stop() {
+	n.path, n.status = nil, NavigationIdle
+}
+
+// Path returns the path currently followed. Nil is returned if the Navigator is not following a path.
+func (n *Navigator) Path() *path.Path {
+	return n.path
+}
+
+// Target returns the position that the Navigator was last told to move to.
+func (n *Navigator) Target() mgl64.Vec3 {
+	return n.target
+}
+
+// Status returns the current NavigationStatus of the Navigator.
+func (n *Navigator) Status() NavigationStatus {
+	return n.status
+}
+
+// Moving checks if the Navigator is currently following a path.
+func (n *Navigator) Moving() bool {
+	return n.status == NavigationMoving
+}
+
+// compute computes a new path to the target of the Navigator.
+func (n *Navigator) compute(l *Living) bool {
+	conf := n.conf
+	if conf.BBox == (cube.BBox{}) {
+		conf.BBox = l.entityType.BBox(l)
+	}
+	p, ok := path.Find(l.tx, l.Position(), n.target, conf)
+	if !ok {
+		n.path, n.status = nil, NavigationFailed
+		return false
+	}
+	n.path, n.status, n.lastCheck = p, NavigationMoving, l.Position()
+	return true
+}
+
+// tick moves the entity along the path of the Navigator.
+func (n *Navigator) tick(l *Living) {
+	if n.status != NavigationMoving {
+		return
+	}
+	pos := l.Position()
+	point, ok := n.path.Current()
+	for ok && horizontalDistance(pos, point) < 0.35 && math.Abs(pos[1]-point[1]) < 1 {
+		n.path.Advance()
+		point, ok = n.path.Current()
+	}
+	vel := l.Velocity()
+	if !ok {
+		if n.path.Complete() {
+			n.status = NavigationCompleted
+		} else {
+			n.status = NavigationFailed
+		}
+		n.path = nil
+		vel[0], vel[2] = 0, 0
+		l.data.Vel = vel
+		return
+	}
+
+	if n.ticks++; n.ticks%20 == 0 {
+		if horizontalDistance(pos, n.lastCheck) < 0.5 {
+			// The entity has barely moved in the last second, so it is probably stuck.
+			if n.retries++; n.retries > 3 || !n.compute(l) {
+				n.path, n.status = nil, NavigationFailed
+				return
+			}
+			point, _ = n.path.Current()
+		}
+		n.lastCheck = pos
+	}
+
+	dir := point.Sub(pos)
+	dir[1] = 0
+	if dir.Len() > mgl64.Epsilon {
+		dir = dir.Normalize()
+	}
+	vel[0], vel[2] = dir[0]*l.Speed(), dir[2]*l.Speed()
+	if point[1]-pos[1] > 0.01 && l.OnGround() {
+		vel[1] = 0.42
+	}
+	l.data.Vel = vel
+
+	// The rotation is sent directly rather than through Move, which would reset the velocity set above.
+	if yaw, _ := LookAtExtended(pos, point); !mgl64.FloatEqual(yaw, l.Rotation().Yaw()) {
+		l.data.Rot[0] = yaw
+		for _, v := range l.Viewers() {
+			v.ViewEntityMovement(l, pos, l.data.Rot, l.OnGround())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package living
+
+import (
+	"fmt"
+	"testing"
+	_ "unsafe"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+//go:linkname finaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func finaliseBlockRegistry()
+
+func init() {
+	// Blocks are normally registered when the server is created, which tests do not do.
+	finaliseBlockRegistry()
+}
+
+type testEntityType struct{}
+
+func (testEntityType) EncodeEntity() string { return "living:test" }
+func (testEntityType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (testEntityType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Living{tx: tx, handle: handle, data: data, livingData: data.Data.(*livingData)}
+}
+func (testEntityType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (testEntityType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
+
+// TestNavigatorTurning checks that an entity following a path keeps moving on the ticks that it turns.
+func TestNavigatorTurning(t *testing.T) {
+	w := world.Config{
+		Generator: generator.NewFlat(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Bedrock{}}),
+	}.New()
+	defer w.Close()
+
+	// t.Fatal cannot be called from the goroutine running the transaction, so failures are reported after it.
+	var failure string
+	<-w.Exec(func(tx *world.Tx) {
+		// The flat world is three blocks high. A wall forces the entity to walk around it, turning on the way.
+		y := tx.Range()[0] + 3
+		for z := -3; z <= 3; z++ {
+			tx.SetBlock(cube.Pos{2, y, z}, block.Stone{}, nil)
+			tx.SetBlock(cube.Pos{2, y + 1, z}, block.Stone{}, nil)
+		}
+		conf := Config{EntityType: testEntityType{}, Speed: 0.1, MaxHealth: 20}
+		opts := world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, float64(y), 0.5}}
+		l := tx.AddEntity(opts.New(testEntityType{}, conf)).(*Living)
+		l.onGround = true
+
+		if !l.Navigator().MoveTo(l, mgl64.Vec3{4.5, float64(y), 0.5}) {
+			failure = "expected a path to be found"
+			return
+		}
+		turned := false
+		for i := 0; i < 200 && l.Navigator().Moving(); i++ {
+			yaw := l.Rotation().Yaw()
+			l.navigator.tick(l)
+			if !l.Navigator().Moving() {
+				break
+			}
+			if vel := l.Velocity(); vel[0] == 0 && vel[2] == 0 {
+				failure = fmt.Sprintf("tick %v: entity stopped moving while following its path", i)
+				return
+			}
+			if !mgl64.FloatEqual(yaw, l.Rotation().Yaw()) {
+				turned = true
+			}
+			l.data.Pos = l.data.Pos.Add(mgl64.Vec3{l.Velocity()[0], 0, l.Velocity()[2]})
+		}
+		if !turned {
+			failure = "expected the entity to turn while following its path"
+		}
+	})
+	if failure != "" {
+		t.Fatal(failure)
+	}
+}
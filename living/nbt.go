@@ -0,0 +1,357 @@
+package living
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// encodeNBT encodes the livingData to a map of properties that can be encoded to NBT. The position, rotation,
+// velocity and name tag of the entity are encoded by the world itself.
+func (d *livingData) encodeNBT() map[string]any {
+	m := map[string]any{
+		"LivingID":      d.identifier,
+		"Health":        float32(d.Health()),
+		"MaxHealth":     float32(d.MaxHealth()),
+		"Absorption":    float32(d.absorption),
+		"ActiveEffects": encodeEffects(append(d.effects.Effects(), d.loadedEffects...)),
+		"Fire":          int16(min(d.fireTicks, math.MaxInt16)),
+		"FallDistance":  float32(d.fallDistance),
+		"Air":           int16(d.airTicks),
+		"TicksFrozen":   int32(d.frozenTicks),
+		"TicksLived":    int64(d.age / (time.Second / 20)),
+		"Variant":       d.variant,
+		"MarkVariant":   d.markVariant,
+		"Scale":         float32(d.scale),
+		"Invisible":     boolByte(d.invisible),
+		"Immobile":      boolByte(d.immobile),
+		"Armor":         encodeItems(d.armour.Slots()),
+		"Mainhand":      encodeItems([]item.Stack{d.mainHand}),
+		"Offhand":       encodeItems([]item.Stack{d.offHand}),
+		"DropChances":   encodeDropChances(d.dropChances),
+		"Inventory":     encodeItems(d.inventory.Slots()),
+		"CanPickUpLoot": boolByte(d.canPickUpItems),
+	}
+	if d.dying {
+		m["DeathTime"] = int16(min(d.deathTicks, math.MaxInt16))
+	}
+	return m
+}
+
+// decodeNBT decodes the properties encoded by encodeNBT into the livingData.
+func (d *livingData) decodeNBT(m map[string]any) {
+	if maxHealth, ok := m["MaxHealth"].(float32); ok {
+		health, ok := m["Health"].(float32)
+		if !ok {
+			health = maxHealth
+		}
+		d.HealthManager = entity.NewHealthManager(float64(health), float64(maxHealth))
+	}
+	d.absorption = float64(readFloat32(m, "Absorption"))
+	d.effects, d.loadedEffects = entity.NewEffectManager(), decodeEffects(m["ActiveEffects"])
+	d.fireTicks = int64(readInt16(m, "Fire"))
+	d.fallDistance = float64(readFloat32(m, "FallDistance"))
+	if air, ok := m["Air"].(int16); ok {
+		d.airTicks, d.breathing = int64(air), int64(air) >= d.maxAirTicks
+	}
+	d.frozenTicks = min(int64(readInt32(m, "TicksFrozen")), freezeTicks)
+	d.age = time.Duration(readInt64(m, "TicksLived")) * time.Second / 20
+	d.variant = readInt32(m, "Variant")
+	d.markVariant = readInt32(m, "MarkVariant")
+	if scale := readFloat32(m, "Scale"); scale > 0 {
+		d.scale = float64(scale)
+	}
+	d.invisible = readBool(m, "Invisible")
+	d.immobile = readBool(m, "Immobile")
+	if armour := decodeItems(m["Armor"]); len(armour) == 4 {
+		d.armour.Set(armour[0], armour[1], armour[2], armour[3])
+	}
+	if mainHand := decodeItems(m["Mainhand"]); len(mainHand) == 1 {
+		d.mainHand = mainHand[0]
+	}
+	if offHand := decodeItems(m["Offhand"]); len(offHand) == 1 {
+		d.offHand = offHand[0]
+	}
+	if chances := readFloat32s(m, "DropChances"); len(chances) == len(d.dropChances) {
+		for i, chance := range chances {
+			d.dropChances[i] = float64(chance)
+		}
+	}
+	for slot, s := range decodeItems(m["Inventory"]) {
+		_ = d.inventory.SetItem(slot, s)
+	}
+	if _, ok := m["CanPickUpLoot"]; ok {
+		d.canPickUpItems = readBool(m, "CanPickUpLoot")
+	}
+	if deathTime, ok := m["DeathTime"].(int16); ok && d.Health() <= mgl64.Epsilon {
+		// The entity was saved while playing its death animation.
+		d.dying, d.deathTicks = true, int64(deathTime)
+	}
+}
+
+// encodeEffects encodes a list of effects to a list of NBT compounds.
+func encodeEffects(effects []effect.Effect) []map[string]any {
+	list := make([]map[string]any, 0, len(effects))
+	for _, e := range effects {
+		id, ok := effect.ID(e.Type())
+		if !ok {
+			continue
+		}
+		dur := int32(e.Duration() / (time.Second / 20))
+		if e.Infinite() {
+			dur = -1
+		}
+		list = append(list, map[string]any{
+			"Id":            uint8(id),
+			"Amplifier":     uint8(e.Level() - 1),
+			"Duration":      dur,
+			"Ambient":       boolByte(e.Ambient()),
+			"ShowParticles": boolByte(!e.ParticlesHidden()),
+		})
+	}
+	return list
+}
+
+// decodeEffects decodes a list of NBT compounds encoded using encodeEffects.
+func decodeEffects(v any) []effect.Effect {
+	var effects []effect.Effect
+	for _, m := range readCompounds(v) {
+		t, ok := effect.ByID(int(readUint8(m, "Id")))
+		if !ok {
+			continue
+		}
+		lasting, ok := t.(effect.LastingType)
+		if !ok {
+			continue
+		}
+		lvl, dur := int(readUint8(m, "Amplifier"))+1, readInt32(m, "Duration")
+
+		var e effect.Effect
+		switch {
+		case dur < 0:
+			e = effect.NewInfinite(lasting, lvl)
+		case readBool(m, "Ambient"):
+			e = effect.NewAmbient(lasting, lvl, time.Duration(dur)*time.Second/20)
+		default:
+			e = effect.New(lasting, lvl, time.Duration(dur)*time.Second/20)
+		}
+		if !readBool(m, "ShowParticles") {
+			e = e.WithoutParticles()
+		}
+		effects = append(effects, e)
+	}
+	return effects
+}
+
+// encodeItems encodes a list of item stacks to a list of NBT compounds. Empty stacks are encoded as empty
+// compounds, so that the index of each stack is kept.
+func encodeItems(stacks []item.Stack) []map[string]any {
+	list := make([]map[string]any, 0, len(stacks))
+	for _, s := range stacks {
+		list = append(list, encodeItem(s))
+	}
+	return list
+}
+
+// decodeItems decodes a list of NBT compounds encoded using encodeItems.
+func decodeItems(v any) []item.Stack {
+	compounds := readCompounds(v)
+	stacks := make([]item.Stack, 0, len(compounds))
+	for _, m := range compounds {
+		stacks = append(stacks, decodeItem(m))
+	}
+	return stacks
+}
+
+// encodeItem encodes an item stack to an NBT compound in the format used by vanilla, including its
+// durability, enchantments, custom name and lore.
+func encodeItem(s item.Stack) map[string]any {
+	if s.Empty() {
+		return map[string]any{}
+	}
+	tag := map[string]any{}
+	if nbt, ok := s.Item().(world.NBTer); ok {
+		maps.Copy(tag, nbt.EncodeNBT())
+	}
+	if _, ok := s.Item().(item.Durable); ok {
+		tag["Damage"] = int16(s.MaxDurability() - s.Durability())
+	}
+	if s.Unbreakable() {
+		tag["Unbreakable"] = uint8(1)
+	}
+	if cost := s.AnvilCost(); cost > 0 {
+		tag["RepairCost"] = int32(cost)
+	}
+	var enchantments []map[string]any
+	for _, e := range s.Enchantments() {
+		if id, ok := item.EnchantmentID(e.Type()); ok {
+			enchantments = append(enchantments, map[string]any{"id": int16(id), "lvl": int16(e.Level())})
+		}
+	}
+	if len(enchantments) > 0 {
+		tag["ench"] = enchantments
+	}
+	display := map[string]any{}
+	if name := s.CustomName(); name != "" {
+		display["Name"] = name
+	}
+	if lore := s.Lore(); len(lore) > 0 {
+		display["Lore"] = lore
+	}
+	if len(display) > 0 {
+		tag["display"] = display
+	}
+
+	name, meta := s.Item().EncodeItem()
+	m := map[string]any{"Name": name, "Damage": meta, "Count": uint8(s.Count())}
+	if b, ok := s.Item().(world.Block); ok {
+		name, properties := b.EncodeBlock()
+		m["Block"] = map[string]any{"name": name, "states": properties, "version": chunk.CurrentBlockVersion}
+	}
+	if len(tag) > 0 {
+		m["tag"] = tag
+	}
+	return m
+}
+
+// decodeItem decodes an NBT compound encoded using encodeItem. An empty stack is returned if the item is not
+// registered.
+func decodeItem(m map[string]any) item.Stack {
+	name, _ := m["Name"].(string)
+	it, ok := world.ItemByName(name, readInt16(m, "Damage"))
+	if !ok {
+		if b, ok := m["Block"].(map[string]any); ok {
+			name, _ := b["name"].(string)
+			properties, _ := b["states"].(map[string]any)
+			if bl, ok := world.BlockByName(name, properties); ok {
+				it, _ = bl.(world.Item)
+			}
+		}
+	}
+	if it == nil {
+		return item.Stack{}
+	}
+	tag, _ := m["tag"].(map[string]any)
+	if nbt, ok := it.(world.NBTer); ok && tag != nil {
+		it = nbt.DecodeNBT(tag).(world.Item)
+	}
+	s := item.NewStack(it, int(readUint8(m, "Count")))
+	if tag == nil {
+		return s
+	}
+	s = s.Damage(int(readInt16(tag, "Damage"))).WithAnvilCost(int(readInt32(tag, "RepairCost")))
+	if readBool(tag, "Unbreakable") {
+		s = s.AsUnbreakable()
+	}
+	for _, e := range readCompounds(tag["ench"]) {
+		if t, ok := item.EnchantmentByID(int(readInt16(e, "id"))); ok {
+			s = s.WithEnchantments(item.NewEnchantment(t, int(readInt16(e, "lvl"))))
+		}
+	}
+	if display, ok := tag["display"].(map[string]any); ok {
+		if name, ok := display["Name"].(string); ok {
+			s = s.WithCustomName(name)
+		}
+		switch lore := display["Lore"].(type) {
+		case []string:
+			s = s.WithLore(lore...)
+		case []any:
+			lines := make([]string, 0, len(lore))
+			for _, l := range lore {
+				if line, ok := l.(string); ok {
+					lines = append(lines, line)
+				}
+			}
+			s = s.WithLore(lines...)
+		}
+	}
+	return s
+}
+
+// encodeDropChances encodes the drop chances of the equipment of an entity to a list of floats.
+func encodeDropChances(chances [6]float64) []float32 {
+	list := make([]float32, len(chances))
+	for i, chance := range chances {
+		list[i] = float32(chance)
+	}
+	return list
+}
+
+// readCompounds reads a list of NBT compounds, which may either be a []map[string]any or a []any after being
+// decoded from disk.
+func readCompounds(v any) []map[string]any {
+	switch v := v.(type) {
+	case []map[string]any:
+		return v
+	case []any:
+		list := make([]map[string]any, 0, len(v))
+		for _, e := range v {
+			if m, ok := e.(map[string]any); ok {
+				list = append(list, m)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// boolByte converts a bool to a byte as used in NBT.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func readBool(m map[string]any, k string) bool {
+	return readUint8(m, k) == 1
+}
+
+func readUint8(m map[string]any, k string) uint8 {
+	v, _ := m[k].(uint8)
+	return v
+}
+
+func readInt16(m map[string]any, k string) int16 {
+	v, _ := m[k].(int16)
+	return v
+}
+
+func readInt32(m map[string]any, k string) int32 {
+	v, _ := m[k].(int32)
+	return v
+}
+
+func readInt64(m map[string]any, k string) int64 {
+	v, _ := m[k].(int64)
+	return v
+}
+
+func readFloat32(m map[string]any, k string) float32 {
+	v, _ := m[k].(float32)
+	return v
+}
+
+// readFloat32s reads a list of floats, which may either be a []float32 or a []any after being decoded from
+// disk.
+func readFloat32s(m map[string]any, k string) []float32 {
+	switch v := m[k].(type) {
+	case []float32:
+		return v
+	case []any:
+		list := make([]float32, 0, len(v))
+		for _, e := range v {
+			f, _ := e.(float32)
+			list = append(list, f)
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package path
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Source is a source of blocks and liquids that paths are computed in. *world.Tx implements Source.
+type Source interface {
+	world.BlockSource
+	// Liquid returns the liquid at the position passed, if there is any.
+	Liquid(pos cube.Pos) (world.Liquid, bool)
+}
+
+// Config holds the settings used to compute a Path for an entity. Fields left empty are filled with values
+// matching those of most vanilla mobs.
+type Config struct {
+	// BBox is the bounding box of the entity, relative to its feet.
+	BBox cube.BBox
+	// StepHeight is the maximum height the entity can walk up without jumping. If 0, a step height of 0.6 is
+	// used.
+	StepHeight float64
+	// JumpHeight is the maximum height the entity can jump up. If 0, a jump height of 1.125 is used.
+	JumpHeight float64
+	// MaxFallDistance is the maximum amount of blocks the entity is willing to drop down. If 0, a maximum fall
+	// distance of 3 is used.
+	MaxFallDistance int
+	// MaxNodes is the maximum amount of nodes that are evaluated before the search is given up. If 0, 1024
+	// nodes are evaluated at most.
+	MaxNodes int
+	// Avoid is called to check if a block is dangerous for the entity to walk into or stand on. If nil,
+	// Dangerous is used.
+	Avoid func(b world.Block) bool
+}
+
+// withDefaults returns a copy of the Config with its empty fields filled with default values.
+func (conf Config) withDefaults() Config {
+	if conf.StepHeight == 0 {
+		conf.StepHeight = 0.6
+	}
+	if conf.JumpHeight == 0 {
+		conf.JumpHeight = 1.125
+	}
+	if conf.MaxFallDistance == 0 {
+		conf.MaxFallDistance = 3
+	}
+	if conf.MaxNodes == 0 {
+		conf.MaxNodes = 1024
+	}
+	if conf.Avoid == nil {
+		conf.Avoid = Dangerous
+	}
+	return conf
+}
+
+// Dangerous checks if a block hurts entities walking into or standing on it, such as lava, fire or cactus.
+func Dangerous(b world.Block) bool {
+	switch b := b.(type) {
+	case block.Lava, block.Fire, block.Cactus:
+		return true
+	case block.Campfire:
+		return !b.Extinguished
+	}
+	return false
+}
+
+// epsilon is the margin used for collision checks, so that entities are not considered to collide with blocks
+// they are merely touching.
+const epsilon = 0.001
+
+// Find computes a Path from start to end using A*. If end cannot be reached, a Path to the point closest to
+// end is returned, for which Path.Complete returns false. Find returns false if no Path that gets any closer to
+// end could be found.
+func Find(src Source, start, end mgl64.Vec3, conf Config) (*Path, bool) {
+	f := &finder{src: src, conf: conf.withDefaults(), boxes: make(map[cube.Pos][]cube.BBox)}
+	return f.find(start, end)
+}
+
+// finder holds the state of a single path search.
+type finder struct {
+	src   Source
+	conf  Config
+	boxes map[cube.Pos][]cube.BBox
+}
+
+// node is a position evaluated during a path search.
+type node struct {
+	pos    cube.Pos
+	floor  float64
+	g, h   float64
+	parent *node
+	index  int
+	closed bool
+}
+
+// point returns the position of the feet of an entity standing at the node.
+func (n *node) point() mgl64.Vec3 {
+	return mgl64.Vec3{float64(n.pos[0]) + 0.5, float64(n.pos[1]) + n.floor, float64(n.pos[2]) + 0.5}
+}
+
+// find runs the A* search from start to end.
+func (f *finder) find(start, end mgl64.Vec3) (*Path, bool) {
+	startPos, goal := cube.PosFromVec3(start), cube.PosFromVec3(end)
+	first := &node{pos: startPos, floor: start[1] - float64(startPos[1])}
+	if h, ok := f.floor(startPos); ok {
+		first.floor = h
+	}
+	first.h = first.point().Sub(end).Len()
+
+	nodes := map[cube.Pos]*node{startPos: first}
+	open := &queue{first}
+	closest := first
+
+	for evaluated := 0; open.Len() > 0 && evaluated < f.conf.MaxNodes; evaluated++ {
+		cur := heap.Pop(open).(*node)
+		cur.closed = true
+		if cur.h < closest.h {
+			closest = cur
+		}
+		if cur.pos == goal {
+			return f.path(cur, true), true
+		}
+		for _, next := range f.neighbours(cur) {
+			n, ok := nodes[next.pos]
+			if ok && (n.closed || n.g <= next.g) {
+				continue
+			}
+			next.parent, next.h = cur, next.point().Sub(end).Len()
+			if ok {
+				// A cheaper route to a node still in the open set was found, so its position in the queue has to
+				// be restored.
+				n.floor, n.g, n.h, n.parent = next.floor, next.g, next.h, cur
+				heap.Fix(open, n.index)
+				continue
+			}
+			nodes[next.pos] = next
+			heap.Push(open, next)
+		}
+	}
+	if closest == first {
+		return nil, false
+	}
+	return f.path(closest, false), true
+}
+
+// path builds a Path leading up to the node passed.
+func (f *finder) path(n *node, complete bool) *Path {
+	var points []mgl64.Vec3
+	for ; n.parent != nil; n = n.parent {
+		points = append(points, n.point())
+	}
+	slices.Reverse(points)
+	return &Path{points: points, complete: complete}
+}
+
+// directions holds the horizontal directions that are evaluated from every node. The last four directions are
+// diagonal.
+var directions = [...]cube.Pos{{1, 0, 0}, {-1, 0, 0}, {0, 0, 1}, {0, 0, -1}, {1, 0, 1}, {1, 0, -1}, {-1, 0, 1}, {-1, 0, -1}}
+
+// neighbours returns all nodes that can be walked, jumped or dropped to from the node passed.
+func (f *finder) neighbours(cur *node) []*node {
+	feet := float64(cur.pos[1]) + cur.floor
+	neighbours := make([]*node, 0, len(directions))
+	for i, dir := range directions {
+		pos := cur.pos.Add(dir)
+		if i >= 4 {
+			// Only walk diagonally on flat ground without cutting any corners.
+			h, ok := f.floor(pos)
+			if !ok || math.Abs(float64(pos[1])+h-feet) > f.conf.StepHeight {
+				continue
+			}
+			top := max(feet, float64(pos[1])+h)
+			if !f.clear(cur.pos.Add(cube.Pos{dir[0]}), top) || !f.clear(cur.pos.Add(cube.Pos{0, 0, dir[2]}), top) {
+				continue
+			}
+			neighbours = append(neighbours, &node{pos: pos, floor: h, g: cur.g + math.Sqrt2})
+			continue
+		}
+		if n, ok := f.climb(cur, pos, feet); ok {
+			neighbours = append(neighbours, n)
+		} else if n, ok := f.drop(cur, pos, feet); ok {
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours
+}
+
+// climb attempts to walk or jump from the node passed to the column of pos.
+func (f *finder) climb(cur *node, pos cube.Pos, feet float64) (*node, bool) {
+	for dy := 0; float64(dy) <= math.Ceil(f.conf.JumpHeight); dy++ {
+		p := pos.Add(cube.Pos{0, dy})
+		h, ok := f.floor(p)
+		if !ok {
+			continue
+		}
+		rise := float64(p[1]) + h - feet
+		if rise > f.conf.JumpHeight {
+			return nil, false
+		}
+		if rise <= f.conf.StepHeight {
+			return &node{pos: p, floor: h, g: cur.g + 1}, true
+		}
+		if !f.clear(cur.pos, feet+rise) {
+			// Not enough room above the entity to jump.
+			return nil, false
+		}
+		return &node{pos: p, floor: h, g: cur.g + 1.5}, true
+	}
+	return nil, false
+}
+
+// drop attempts to walk off the edge of the node passed, dropping down in the column of pos.
+func (f *finder) drop(cur *node, pos cube.Pos, feet float64) (*node, bool) {
+	if !f.clear(pos, feet) {
+		return nil, false
+	}
+	for dy := -1; dy >= -f.conf.MaxFallDistance-1; dy-- {
+		p := pos.Add(cube.Pos{0, dy})
+		if h, ok := f.floor(p); ok {
+			fall := feet - float64(p[1]) - h
+			if fall > float64(f.conf.MaxFallDistance) {
+				return nil, false
+			}
+			return &node{pos: p, floor: h, g: cur.g + 1 + fall*0.5}, true
+		}
+		if !f.clear(p, float64(p[1])) {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// floor returns the height above the bottom of the block at pos that an entity with its feet in that block
+// stands at. False is returned if the entity cannot stand there.
+func (f *finder) floor(pos cube.Pos) (float64, bool) {
+	h := math.Inf(-1)
+	for _, box := range f.collisions(pos) {
+		h = max(h, box.Max()[1])
+	}
+	below := pos.Side(cube.FaceDown)
+	for _, box := range f.collisions(below) {
+		h = max(h, box.Max()[1]-1)
+	}
+	if h < -epsilon || h >= 1 {
+		return 0, false
+	}
+	h = max(h, 0)
+	if f.dangerous(below) || !f.clear(pos, float64(pos[1])+h) {
+		return 0, false
+	}
+	return h, true
+}
+
+// clear checks if the entity fits in the column of pos with its feet at the height passed, without colliding
+// with any blocks or touching dangerous blocks.
+func (f *finder) clear(pos cube.Pos, feet float64) bool {
+	box := f.conf.BBox.Translate(mgl64.Vec3{float64(pos[0]) + 0.5, feet, float64(pos[2]) + 0.5}).Grow(-epsilon)
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for y := low[1]; y <= high[1]; y++ {
+		for x := low[0]; x <= high[0]; x++ {
+			for z := low[2]; z <= high[2]; z++ {
+				p := cube.Pos{x, y, z}
+				if f.dangerous(p) {
+					return false
+				}
+				for _, b := range f.collisions(p) {
+					if b.Translate(p.Vec3()).IntersectsWith(box) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// dangerous checks if the block or liquid at pos should be avoided.
+func (f *finder) dangerous(pos cube.Pos) bool {
+	if f.conf.Avoid(f.src.Block(pos)) {
+		return true
+	}
+	liq, ok := f.src.Liquid(pos)
+	return ok && f.conf.Avoid(liq)
+}
+
+// collisions returns the collision boxes of the block at pos, relative to the block's position.
+func (f *finder) collisions(pos cube.Pos) []cube.BBox {
+	if boxes, ok := f.boxes[pos]; ok {
+		return boxes
+	}
+	boxes := f.src.Block(pos).Model().BBox(pos, f.src)
+	f.boxes[pos] = boxes
+	return boxes
+}
+
+// queue is a priority queue of nodes, ordered by their estimated total cost.
+type queue []*node
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].g+q[i].h < q[j].g+q[j].h }
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+func (q *queue) Push(x any) {
+	n := x.(*node)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+
+func (q *queue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	n.index = -1
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,63 @@
+package path
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Path is a sequence of points an entity may walk along to reach a destination. Each point is the position
+// of the feet of the entity, centred in the block it stands in.
+type Path struct {
+	points   []mgl64.Vec3
+	index    int
+	complete bool
+}
+
+// Points returns all points of the Path, including the ones that have already been passed.
+func (p *Path) Points() []mgl64.Vec3 {
+	return p.points
+}
+
+// Len returns the total amount of points in the Path.
+func (p *Path) Len() int {
+	return len(p.points)
+}
+
+// Index returns the index of the current point of the Path.
+func (p *Path) Index() int {
+	return p.index
+}
+
+// Current returns the point the entity following the Path is currently walking towards. False is returned
+// if the Path is finished.
+func (p *Path) Current() (mgl64.Vec3, bool) {
+	if p.Finished() {
+		return mgl64.Vec3{}, false
+	}
+	return p.points[p.index], true
+}
+
+// Advance moves on to the next point of the Path.
+func (p *Path) Advance() {
+	if !p.Finished() {
+		p.index++
+	}
+}
+
+// Finished checks if all points of the Path have been passed.
+func (p *Path) Finished() bool {
+	return p.index >= len(p.points)
+}
+
+// Complete checks if the Path leads all the way to the destination it was computed for. If false, the Path
+// only leads to the reachable point closest to the destination.
+func (p *Path) Complete() bool {
+	return p.complete
+}
+
+// Destination returns the last point of the Path.
+func (p *Path) Destination() mgl64.Vec3 {
+	if len(p.points) == 0 {
+		return mgl64.Vec3{}
+	}
+	return p.points[len(p.points)-1]
+}
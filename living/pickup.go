@@ -0,0 +1,142 @@
+package living
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const (
+	// inventorySize is the size of the inventory that items picked up by an entity without equipping them are
+	// stored in.
+	inventorySize = 8
+	// pickupDelay is the minimum age of item entities before they are picked up by living entities.
+	pickupDelay = time.Second / 2
+)
+
+// Inventory returns the inventory that items picked up by the entity are stored in if they are not equipped.
+// The items in the inventory are dropped when the entity dies.
+func (l *Living) Inventory() *inventory.Inventory {
+	return l.inventory
+}
+
+// CanPickUpItems checks if the entity picks up items lying on the ground near it.
+func (l *Living) CanPickUpItems() bool {
+	return l.canPickUpItems
+}
+
+// SetCanPickUpItems sets if the entity picks up items lying on the ground near it.
+func (l *Living) SetCanPickUpItems(v bool) {
+	l.canPickUpItems = v
+}
+
+// tickPickup picks up an item entity lying on the ground near the entity, if it can pick up items. At most one
+// item entity is picked up per tick.
+func (l *Living) tickPickup(tx *world.Tx) {
+	if !l.canPickUpItems {
+		return
+	}
+	box := l.entityType.BBox(l).Translate(l.Position())
+	for e := range tx.EntitiesWithin(box.GrowVec3(mgl64.Vec3{1, 0.5, 1})) {
+		ent, ok := e.(*entity.Ent)
+		if !ok || e.H().Type() != entity.ItemType || ent.Age() < pickupDelay {
+			continue
+		}
+		s := ent.Behaviour().(*entity.ItemBehaviour).Item()
+		n := l.collect(s)
+		if n == 0 {
+			// Nothing was picked up, for example because the inventory of the entity is full, so the item is
+			// left on the ground.
+			continue
+		}
+		for _, v := range tx.Viewers(ent.Position()) {
+			v.ViewEntityAction(ent, entity.PickedUpAction{Collector: l})
+		}
+		if n < s.Count() {
+			tx.AddEntity(entity.NewItem(world.EntitySpawnOpts{Position: ent.Position()}, s.Grow(-n)))
+		}
+		_ = ent.Close()
+		return
+	}
+}
+
+// collect collects an item stack lying on the ground near the entity and returns the amount of items picked
+// up. Equipment better than the item currently in its slot, as decided by the pickup comparator of the entity,
+// is equipped. Equipment that is not better is left on the ground, while other items are stored in the
+// Inventory of the entity.
+func (l *Living) collect(s item.Stack) int {
+	slot, equip := equipmentSlot(s)
+	if equip && !l.pickupComparator(l.Equipped(slot), s) {
+		return 0
+	}
+	ctx := event.C(l)
+	if l.handler.HandlePickup(ctx, s, &equip); ctx.Cancelled() {
+		return 0
+	}
+	if equip {
+		// The handler may have decided to equip an item that cannot be equipped, in which case it is stored in
+		// the inventory instead.
+		slot, equip = equipmentSlot(s)
+	}
+	if !equip {
+		n, _ := l.inventory.AddItem(s)
+		return n
+	}
+	old := l.Equipped(slot)
+	if err := l.Equip(slot, s.Grow(1-s.Count())); err != nil {
+		return 0
+	}
+	if !old.Empty() && l.dropChances[slot] >= 1 {
+		// Only items that were picked up before are dropped when replaced, like in vanilla.
+		l.tx.AddEntity(entity.NewItem(world.EntitySpawnOpts{Position: l.Position()}, old))
+	}
+	l.SetDropChance(slot, 1)
+	return 1
+}
+
+// BetterEquipment is the default pickup comparator of entities. It checks if the candidate stack is better to
+// equip than the current stack, comparing defence points and toughness of armour and attack damage of
+// weapons. Between otherwise equal items, the one with the most durability left is better.
+func BetterEquipment(current, candidate item.Stack) bool {
+	if current.Empty() {
+		return true
+	}
+	ca, ok := current.Item().(item.Armour)
+	na, ok2 := candidate.Item().(item.Armour)
+	if ok && ok2 {
+		if ca.DefencePoints() != na.DefencePoints() {
+			return na.DefencePoints() > ca.DefencePoints()
+		}
+		if ca.Toughness() != na.Toughness() {
+			return na.Toughness() > ca.Toughness()
+		}
+		return candidate.Durability() > current.Durability()
+	}
+	if current.AttackDamage() != candidate.AttackDamage() {
+		return candidate.AttackDamage() > current.AttackDamage()
+	}
+	return candidate.Durability() > current.Durability()
+}
+
+// equipmentSlot returns the EquipmentSlot that the item stack passed is equipped in if picked up. False is
+// returned if the item is not equipped, but stored in the inventory of the entity instead.
+func equipmentSlot(s item.Stack) (EquipmentSlot, bool) {
+	switch s.Item().(type) {
+	case item.HelmetType:
+		return SlotHelmet, true
+	case item.ChestplateType:
+		return SlotChestplate, true
+	case item.LeggingsType:
+		return SlotLeggings, true
+	case item.BootsType:
+		return SlotBoots, true
+	case item.Weapon:
+		return SlotMainHand, true
+	}
+	return 0, false
+}
@@ -0,0 +1,118 @@
+package living
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Projectile holds the properties of a projectile shot using Living.ShootProjectile.
+type Projectile struct {
+	// New creates the projectile entity with the spawn options and owner passed. Constructors such as
+	// entity.NewArrow and entity.NewSnowball may be used directly. Projectiles not implemented by dragonfly,
+	// such as tridents and fireballs, may be shot by passing a custom constructor.
+	New func(opts world.EntitySpawnOpts, owner world.Entity) *world.EntityHandle
+	// Speed is the speed of the projectile in blocks per tick.
+	Speed float64
+	// Gravity is the gravity applied to the projectile every tick, used to aim above targets that are further
+	// away. Projectiles without gravity, such as fireballs, should leave this 0.
+	Gravity float64
+	// Inaccuracy returns the inaccuracy of the projectile for the difficulty passed. Higher values spread the
+	// projectile more. If nil, DifficultyInaccuracy is used.
+	Inaccuracy func(diff world.Difficulty) float64
+}
+
+var (
+	// ArrowProjectile is a Projectile shooting arrows like skeletons do.
+	ArrowProjectile = Projectile{New: entity.NewArrow, Speed: 1.6, Gravity: 0.05}
+	// SnowballProjectile is a Projectile shooting snowballs like snow golems do.
+	SnowballProjectile = Projectile{New: entity.NewSnowball, Speed: 1.6, Gravity: 0.03}
+)
+
+// DifficultyInaccuracy returns the inaccuracy of projectiles shot by mobs in vanilla for the difficulty passed.
+// Mobs are more accurate on higher difficulties.
+func DifficultyInaccuracy(diff world.Difficulty) float64 {
+	id, _ := world.DifficultyID(diff)
+	return float64(14 - id*4)
+}
+
+// ShootProjectile shoots the Projectile passed from the eyes of the entity towards the target position. The
+// projectile is aimed above the target to compensate for its gravity. Any charge started using StartCharging
+// is stopped. The projectile entity added to the world is returned.
+func (l *Living) ShootProjectile(target mgl64.Vec3, p Projectile) world.Entity {
+	l.StopCharging()
+
+	eye := entity.EyePosition(l)
+	dir := aim(target.Sub(eye), p.Speed, p.Gravity)
+
+	inaccuracy := p.Inaccuracy
+	if inaccuracy == nil {
+		inaccuracy = DifficultyInaccuracy
+	}
+	spread := inaccuracy(l.tx.World().Difficulty()) * 0.0075
+	dir = dir.Add(mgl64.Vec3{rand.NormFloat64() * spread, rand.NormFloat64() * spread, rand.NormFloat64() * spread})
+	vel := dir.Normalize().Mul(p.Speed)
+
+	yaw, pitch := LookAtExtended(eye, eye.Add(vel))
+	l.LookAt(target)
+
+	opts := world.EntitySpawnOpts{Position: eye, Velocity: vel, Rotation: [2]float64{yaw, pitch}}
+	return l.tx.AddEntity(p.New(opts, l))
+}
+
+// aim returns the normalised direction a projectile with the speed and gravity passed should be launched in to
+// hit a target at the offset passed. If the target is out of range, the projectile is launched at an angle of
+// 45 degrees, which makes it travel the furthest.
+func aim(offset mgl64.Vec3, speed, gravity float64) mgl64.Vec3 {
+	horizontal := math.Hypot(offset[0], offset[2])
+	if horizontal < mgl64.Epsilon {
+		if offset.Len() < mgl64.Epsilon {
+			return mgl64.Vec3{0, 1}
+		}
+		return offset.Normalize()
+	}
+	angle := math.Atan2(offset[1], horizontal)
+	if gravity > 0 {
+		v2 := speed * speed
+		if disc := v2*v2 - gravity*(gravity*horizontal*horizontal+2*offset[1]*v2); disc >= 0 {
+			angle = math.Atan((v2 - math.Sqrt(disc)) / (gravity * horizontal))
+		} else {
+			angle = math.Pi / 4
+		}
+	}
+	cos := math.Cos(angle)
+	return mgl64.Vec3{offset[0] / horizontal * cos, math.Sin(angle), offset[2] / horizontal * cos}
+}
+
+// StartCharging makes the entity display the animation of charging a bow or crossbow to viewers, until
+// StopCharging or ShootProjectile is called.
+func (l *Living) StartCharging() {
+	if l.charging {
+		return
+	}
+	l.charging = true
+	l.updateState()
+}
+
+// StopCharging stops the charging animation started using StartCharging.
+func (l *Living) StopCharging() {
+	if !l.charging {
+		return
+	}
+	l.charging = false
+	l.updateState()
+}
+
+// Charging checks if the entity is currently charging a projectile.
+func (l *Living) Charging() bool {
+	return l.charging
+}
+
+// UsingItem checks if the entity is using its held item, which is the case while it is charging a
+// projectile.
+func (l *Living) UsingItem() bool {
+	return l.charging
+}
@@ -0,0 +1,23 @@
+package living
+
+// factories holds all Config factories registered using Register, indexed by their identifier.
+var factories = map[string]func() Config{}
+
+// Register registers a factory that creates the Config for living entities with the identifier passed. When a
+// living entity is decoded from NBT, the factory registered with its identifier is used to restore its
+// Handler, drops, goals and movement, after which its saved state is applied on top.
+// The identifier is the Identifier set in the Config, or the EncodeEntity() value of its EntityType if empty.
+// Register should be called before any worlds are loaded, and panics if the identifier is already registered.
+func Register(id string, factory func() Config) {
+	if _, ok := factories[id]; ok {
+		panic("cannot register the same living entity (" + id + ") twice")
+	}
+	factories[id] = factory
+}
+
+// Lookup looks up the Config factory registered with the identifier passed. False is returned if no factory
+// was registered with the identifier.
+func Lookup(id string) (func() Config, bool) {
+	f, ok := factories[id]
+	return f, ok
+}
@@ -0,0 +1,65 @@
+package living
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BurnsInSunlight checks if the entity catches fire when exposed to sunlight.
+func (l *Living) BurnsInSunlight() bool {
+	return l.burnsInSunlight
+}
+
+// tickSunlight sets the entity on fire if it burns in sunlight and is exposed to the sky during the day. A
+// helmet protects the entity from burning, but is damaged instead.
+func (l *Living) tickSunlight(tx *world.Tx) {
+	if !l.burnsInSunlight || l.fireImmune || !l.sunBurnTick(tx) {
+		return
+	}
+	if helmet := l.armour.Helmet(); !helmet.Empty() {
+		l.armour.SetHelmet(l.damageItem(helmet, rand.IntN(2)))
+		return
+	}
+	if l.OnFireDuration() < time.Second*8 {
+		l.SetOnFire(time.Second * 8)
+	}
+}
+
+// sunBurnTick checks if the entity should catch fire from sunlight in the current tick. Like in vanilla, the
+// chance of catching fire grows with the light level at the eyes of the entity.
+func (l *Living) sunBurnTick(tx *world.Tx) bool {
+	if !tx.World().Dimension().TimeCycle() || !daytime(tx.World().Time()) {
+		return false
+	}
+	pos := cube.PosFromVec3(entity.EyePosition(l))
+	if tx.HighestLightBlocker(pos[0], pos[2]) > pos[1] || tx.RainingAt(pos) || l.inWater() {
+		return false
+	}
+	brightness := float64(tx.Light(pos)) / 15
+	return brightness > 0.5 && rand.Float64()*30 < (brightness-0.4)*2
+}
+
+// inWater checks if the feet of the entity are currently in water.
+func (l *Living) inWater() bool {
+	liq, ok := l.tx.Liquid(cube.PosFromVec3(l.Position()))
+	if !ok {
+		return false
+	}
+	_, ok = liq.(block.Water)
+	return ok
+}
+
+// daytime checks if the world time passed is during the day, in which the sky is bright enough for undead
+// entities to burn.
+func daytime(t int) bool {
+	t %= 24000
+	if t < 0 {
+		t += 24000
+	}
+	return t < 12000 || t >= 23500
+}
@@ -2,12 +2,17 @@ package living
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/world"
 )
 
 type NopLivingType struct{}
 
 func (n NopLivingType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	if d := data.Data.(*livingData); d.entityType == nil {
+		// Entities decoded from NBT don't know the type they were created with.
+		d.entityType = handle.Type()
+	}
 	l := &Living{
 		livingData: data.Data.(*livingData),
 		tx:         tx,
@@ -26,10 +31,29 @@ func (NopLivingType) BBox(e world.Entity) cube.BBox {
 	return cube.BBox{}
 }
 
+// DecodeNBT decodes the health, effects and state of a living entity. The entity is rebuilt using the Config
+// registered with its identifier using Register. If no Config was registered, the entity is given default
+// movement and a NopHandler.
 func (NopLivingType) DecodeNBT(m map[string]any, data *world.EntityData) {
-	data.Data = m
+	id, _ := m["LivingID"].(string)
+	if id == "" {
+		id, _ = m["identifier"].(string)
+	}
+	conf := Config{
+		Identifier:       id,
+		MaxHealth:        20,
+		MovementComputer: &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+	}
+	if factory, ok := Lookup(id); ok {
+		conf = factory()
+		conf.Identifier = id
+	}
+	d := conf.newData()
+	d.decodeNBT(m)
+	data.Data = d
 }
 
+// EncodeNBT encodes the health, effects and state of a living entity.
 func (NopLivingType) EncodeNBT(data *world.EntityData) map[string]any {
-	return map[string]any{"data": data}
+	return data.Data.(*livingData).encodeNBT()
 }
@@ -15,6 +15,8 @@ import (
 )
 
 func main() {
+	living.Register(entityTypeEnderman{}.EncodeEntity(), endermanConfig)
+
 	log := slog.Default()
 	chat.Global.Subscribe(chat.StdoutSubscriber{})
 
@@ -38,16 +40,27 @@ func accept(p *player.Player) {
 		Position: p.Position(),
 	}
 
-	conf := living.Config{
-		EntityType: entityTypeEnderman{},
-		Handler:    handler{},
-		MaxHealth:  40,
+	conf := endermanConfig()
+	p.Tx().AddEntity(opts.New(conf.EntityType, conf))
+}
+
+func endermanConfig() living.Config {
+	return living.Config{
+		EntityType:   entityTypeEnderman{},
+		Handler:      handler{},
+		MaxHealth:    40,
+		Speed:        0.3,
+		AttackDamage: 7,
 		Drops: []living.Drop{
-			living.NewDrop(item.EnderPearl{}, 0, 2),
+			living.NewDrop(item.EnderPearl{}, 0, 2).WithLootingBonus(1),
 		},
+		Experience:       living.ExperienceDrop{Min: 5, Max: 5, PlayerKillOnly: true},
 		MovementComputer: &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		Goals: []living.PrioritisedGoal{
+			{Priority: 1, Goal: &living.WanderGoal{Radius: 10}},
+			{Priority: 2, Goal: &living.LookAtPlayerGoal{Range: 8}},
+		},
 	}
-	p.Tx().AddEntity(opts.New(conf.EntityType, conf))
 }
 
 type entityTypeEnderman struct {
@@ -65,6 +78,6 @@ type handler struct {
 	living.NopHandler
 }
 
-func (handler) HandleHurt(ctx living.Context, damage float64, immune bool, immunity *time.Duration, src world.DamageSource) {
+func (handler) HandleHurt(ctx *living.Context, damage *living.Damage, immune bool, immunity *time.Duration, src world.DamageSource) {
 	fmt.Println("enderman hurt")
 }